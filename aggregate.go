@@ -0,0 +1,214 @@
+package op
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xsqrty/op/driver"
+)
+
+// AggregateBuilder provides an interface for building aggregate function calls with DISTINCT, ORDER BY and FILTER clauses.
+type AggregateBuilder interface {
+	// Distinct makes the aggregate consider only distinct values of its arguments.
+	Distinct() AggregateBuilder
+	// OrderBy adds ordering criteria inside the aggregate call, which defines the order of the aggregated values.
+	OrderBy(orders ...Order) AggregateBuilder
+	// Filter adds a FILTER (WHERE ...) clause, which limits the rows passed to the aggregate. Conditions are combined using AND.
+	Filter(exp driver.Sqler) AggregateBuilder
+	// Sql generates the aggregate function call, its arguments, and an error if any.
+	Sql(options *driver.SqlOptions) (string, []any, error)
+}
+
+// aggregate represents an aggregate function call with the optional DISTINCT, ORDER BY and FILTER clauses.
+// The dialects map overrides the function name for dialects that have a different equivalent,
+// an empty name means the dialect has no equivalent.
+// The separator is the delimiter of STRING_AGG, which MySQL takes as the SEPARATOR literal instead of the last argument.
+type aggregate struct {
+	name      string
+	dialects  map[driver.Dialect]string
+	args      []any
+	orders    []Order
+	filter    And
+	separator *string
+	distinct  bool
+}
+
+// jsonArrayAggDialects maps the array aggregates to the dialect functions collecting the values into a JSON array.
+var jsonArrayAggDialects = map[driver.Dialect]string{
+	driver.DialectSqlite: "JSON_GROUP_ARRAY",
+	driver.DialectMysql:  "JSON_ARRAYAGG",
+	driver.DialectMssql:  "",
+}
+
+// Agg creates a custom aggregate function call with the specified name and arguments. String arguments are interpreted as columns.
+func Agg(name string, args ...any) AggregateBuilder {
+	return &aggregate{name: name, args: args}
+}
+
+// CountAll generates a SQL COUNT(*) aggregate, which counts all rows.
+func CountAll() AggregateBuilder {
+	return &aggregate{name: "COUNT", args: []any{driver.Pure("*")}}
+}
+
+// StringAgg generates a STRING_AGG aggregate, which concatenates the values into a string separated by the delimiter.
+// Sqlite uses the GROUP_CONCAT equivalent, MySQL uses GROUP_CONCAT with the delimiter as the SEPARATOR literal.
+func StringAgg(arg any, delimiter string) AggregateBuilder {
+	return &aggregate{
+		name:      "STRING_AGG",
+		dialects:  map[driver.Dialect]string{driver.DialectSqlite: "GROUP_CONCAT", driver.DialectMysql: "GROUP_CONCAT"},
+		args:      []any{arg, driver.Value(delimiter)},
+		separator: &delimiter,
+	}
+}
+
+// ArrayAgg generates an ARRAY_AGG aggregate, which collects the values into an array.
+// Sqlite and MySQL have no arrays and use the JSON_GROUP_ARRAY and JSON_ARRAYAGG equivalents, which collect the values into a JSON array.
+// SQL Server has no equivalent.
+func ArrayAgg(arg any) AggregateBuilder {
+	return &aggregate{
+		name:     "ARRAY_AGG",
+		dialects: jsonArrayAggDialects,
+		args:     []any{arg},
+	}
+}
+
+// JsonAgg generates a JSON_AGG aggregate, which collects the values into a JSON array.
+// Sqlite and MySQL use the JSON_GROUP_ARRAY and JSON_ARRAYAGG equivalents, SQL Server has no equivalent.
+func JsonAgg(arg any) AggregateBuilder {
+	return &aggregate{
+		name:     "JSON_AGG",
+		dialects: jsonArrayAggDialects,
+		args:     []any{arg},
+	}
+}
+
+// JsonbAgg generates a JSONB_AGG aggregate, which collects the values into a JSONB array.
+// Sqlite and MySQL use the JSON_GROUP_ARRAY and JSON_ARRAYAGG equivalents, which return the array as JSON.
+// SQL Server has no equivalent.
+func JsonbAgg(arg any) AggregateBuilder {
+	return &aggregate{
+		name:     "JSONB_AGG",
+		dialects: jsonArrayAggDialects,
+		args:     []any{arg},
+	}
+}
+
+// JsonObjectAgg generates a JSON_OBJECT_AGG aggregate, which collects the key/value pairs into a JSON object.
+// Sqlite and MySQL use the JSON_GROUP_OBJECT and JSON_OBJECTAGG equivalents, SQL Server has no equivalent.
+func JsonObjectAgg(key any, value any) AggregateBuilder {
+	return &aggregate{
+		name: "JSON_OBJECT_AGG",
+		dialects: map[driver.Dialect]string{
+			driver.DialectSqlite: "JSON_GROUP_OBJECT",
+			driver.DialectMysql:  "JSON_OBJECTAGG",
+			driver.DialectMssql:  "",
+		},
+		args: []any{key, value},
+	}
+}
+
+// Distinct sets the DISTINCT modifier of the aggregate arguments.
+func (a *aggregate) Distinct() AggregateBuilder {
+	a.distinct = true
+	return a
+}
+
+// OrderBy appends the ordering criteria rendered inside the aggregate call, such as `STRING_AGG("name",? ORDER BY "id" ASC)`.
+// SQL Server renders them in the WITHIN GROUP (ORDER BY ...) clause after the call.
+func (a *aggregate) OrderBy(orders ...Order) AggregateBuilder {
+	a.orders = append(a.orders, orders...)
+	return a
+}
+
+// Filter appends the condition to the FILTER (WHERE ...) clause of the aggregate. Nil conditions are ignored.
+func (a *aggregate) Filter(exp driver.Sqler) AggregateBuilder {
+	if exp != nil {
+		a.filter = append(a.filter, exp)
+	}
+
+	return a
+}
+
+// Sql generates the aggregate function call using the function name of the dialect, such as `COUNT(*) FILTER (WHERE ...)`.
+func (a *aggregate) Sql(options *driver.SqlOptions) (string, []any, error) {
+	name := a.name
+	if dialectName, ok := a.dialects[options.Dialect]; ok {
+		if dialectName == "" {
+			return "", nil, fmt.Errorf("aggregate %s: %w %q", a.name, ErrUnsupportedDialect, options.Dialect)
+		}
+
+		name = dialectName
+	}
+
+	var buf strings.Builder
+	buf.WriteString(name)
+	buf.WriteByte('(')
+	if a.distinct {
+		buf.WriteString("DISTINCT ")
+	}
+
+	aggArgs := a.args
+	withSeparator := a.separator != nil && options.Dialect == driver.DialectMysql
+	if withSeparator {
+		aggArgs = a.args[:len(a.args)-1]
+	}
+
+	sql, args, err := joinList(options.FieldsDelim, aggArgs, true, options)
+	if err != nil {
+		return "", nil, err
+	}
+
+	buf.WriteString(sql)
+	withinGroup := options.Dialect == driver.DialectMssql
+	if len(a.orders) > 0 && !withinGroup {
+		sql, ordersArgs, err := concatFields(a.orders, options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		args = append(args, ordersArgs...)
+		buf.WriteString(" ORDER BY ")
+		buf.WriteString(sql)
+	}
+
+	if withSeparator {
+		separator, err := sqlLiteral(*a.separator, options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		buf.WriteString(" SEPARATOR ")
+		buf.WriteString(separator)
+	}
+
+	buf.WriteByte(')')
+	if len(a.orders) > 0 && withinGroup {
+		sql, ordersArgs, err := concatFields(a.orders, options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		args = append(args, ordersArgs...)
+		buf.WriteString(" WITHIN GROUP (ORDER BY ")
+		buf.WriteString(sql)
+		buf.WriteByte(')')
+	}
+
+	if len(a.filter) > 0 {
+		if options.Dialect == driver.DialectMysql || options.Dialect == driver.DialectMssql {
+			return "", nil, fmt.Errorf("aggregate filter: %w %q", ErrUnsupportedDialect, options.Dialect)
+		}
+
+		buf.WriteString(" FILTER (WHERE ")
+		sql, filterArgs, err := a.filter.Sql(options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		args = append(args, filterArgs...)
+		buf.WriteString(sql)
+		buf.WriteByte(')')
+	}
+
+	return buf.String(), args, nil
+}
@@ -0,0 +1,193 @@
+package op
+
+import (
+	"testing"
+
+	"github.com/xsqrty/op/driver"
+	"github.com/xsqrty/op/internal/testutil"
+)
+
+func TestAggregate(t *testing.T) {
+	t.Parallel()
+	postgres := testutil.NewDialectOptions(driver.DialectPostgres)
+	sqlite := testutil.NewDialectOptions(driver.DialectSqlite)
+
+	testutil.RunCases(t, options, []testutil.TestCase{
+		{
+			Name:         "count_all_filter",
+			Builder:      CountAll().Filter(Gt("total", 100)),
+			ExpectedSql:  `COUNT(*) FILTER (WHERE "total" > ?)`,
+			ExpectedArgs: []any{100},
+		},
+		{
+			Name:         "sum_filter_and",
+			Builder:      Agg("SUM", "total").Filter(Eq("status", "paid")).Filter(nil).Filter(Ne("deleted_at", nil)),
+			ExpectedSql:  `SUM("total") FILTER (WHERE ("status" = ? AND "deleted_at" IS NOT NULL))`,
+			ExpectedArgs: []any{"paid"},
+		},
+		{
+			Name:         "count_distinct_filter",
+			Builder:      Agg("COUNT", "user_id").Distinct().Filter(Eq("status", "paid")),
+			ExpectedSql:  `COUNT(DISTINCT "user_id") FILTER (WHERE "status" = ?)`,
+			ExpectedArgs: []any{"paid"},
+		},
+		{
+			Name:         "string_agg",
+			Builder:      StringAgg("name", ", ").OrderBy(Asc("name"), Desc("id")),
+			ExpectedSql:  `STRING_AGG("name",? ORDER BY "name" ASC,"id" DESC)`,
+			ExpectedArgs: []any{", "},
+			SqlOptions:   postgres,
+		},
+		{
+			Name:         "string_agg_sqlite",
+			Builder:      StringAgg("name", ", ").OrderBy(Asc("name")),
+			ExpectedSql:  `GROUP_CONCAT("name",? ORDER BY "name" ASC)`,
+			ExpectedArgs: []any{", "},
+			SqlOptions:   sqlite,
+		},
+		{
+			Name:         "string_agg_mysql",
+			Builder:      StringAgg("name", ", ").Distinct().OrderBy(Asc("name")),
+			ExpectedSql:  `GROUP_CONCAT(DISTINCT "name" ORDER BY "name" ASC SEPARATOR ', ')`,
+			ExpectedArgs: []any(nil),
+			SqlOptions:   testutil.NewDialectOptions(driver.DialectMysql),
+		},
+		{
+			Name:         "string_agg_mysql_escape",
+			Builder:      StringAgg("name", `\'`),
+			ExpectedSql:  `GROUP_CONCAT("name" SEPARATOR '\\''')`,
+			ExpectedArgs: []any(nil),
+			SqlOptions:   testutil.NewDialectOptions(driver.DialectMysql),
+		},
+		{
+			Name:         "string_agg_mssql",
+			Builder:      StringAgg("name", ", ").OrderBy(Asc("name"), Desc("id")),
+			ExpectedSql:  `STRING_AGG("name",?) WITHIN GROUP (ORDER BY "name" ASC,"id" DESC)`,
+			ExpectedArgs: []any{", "},
+			SqlOptions:   testutil.NewDialectOptions(driver.DialectMssql),
+		},
+		{
+			Name:         "error_array_agg_mssql",
+			Builder:      ArrayAgg("id"),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `aggregate ARRAY_AGG: unsupported in dialect "mssql"`,
+			SqlOptions:   testutil.NewDialectOptions(driver.DialectMssql),
+		},
+		{
+			Name:         "error_json_object_agg_mssql",
+			Builder:      JsonObjectAgg("key", "value"),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `aggregate JSON_OBJECT_AGG: unsupported in dialect "mssql"`,
+			SqlOptions:   testutil.NewDialectOptions(driver.DialectMssql),
+		},
+		{
+			Name:         "array_agg",
+			Builder:      ArrayAgg("id").Distinct().Filter(Gt("age", 18)),
+			ExpectedSql:  `ARRAY_AGG(DISTINCT "id") FILTER (WHERE "age" > ?)`,
+			ExpectedArgs: []any{18},
+			SqlOptions:   postgres,
+		},
+		{
+			Name:         "array_agg_sqlite",
+			Builder:      ArrayAgg("id").OrderBy(Desc("id")),
+			ExpectedSql:  `JSON_GROUP_ARRAY("id" ORDER BY "id" DESC)`,
+			ExpectedArgs: []any(nil),
+			SqlOptions:   sqlite,
+		},
+		{
+			Name:         "json_agg",
+			Builder:      JsonAgg("users.name").OrderBy(Asc("users.id")),
+			ExpectedSql:  `JSON_AGG("users"."name" ORDER BY "users"."id" ASC)`,
+			ExpectedArgs: []any(nil),
+			SqlOptions:   postgres,
+		},
+		{
+			Name:         "jsonb_agg",
+			Builder:      JsonbAgg(Lower("name")),
+			ExpectedSql:  `JSONB_AGG(LOWER("name"))`,
+			ExpectedArgs: []any(nil),
+			SqlOptions:   postgres,
+		},
+		{
+			Name:         "jsonb_agg_sqlite",
+			Builder:      JsonbAgg(Lower("name")),
+			ExpectedSql:  `JSON_GROUP_ARRAY(LOWER("name"))`,
+			ExpectedArgs: []any(nil),
+			SqlOptions:   sqlite,
+		},
+		{
+			Name:         "json_object_agg",
+			Builder:      JsonObjectAgg("key", "value"),
+			ExpectedSql:  `JSON_OBJECT_AGG("key","value")`,
+			ExpectedArgs: []any(nil),
+			SqlOptions:   postgres,
+		},
+		{
+			Name:         "json_object_agg_sqlite",
+			Builder:      JsonObjectAgg("key", Coalesce("value", driver.Value(0))).Filter(Ne("key", nil)),
+			ExpectedSql:  `JSON_GROUP_OBJECT("key",COALESCE("value",?)) FILTER (WHERE "key" IS NOT NULL)`,
+			ExpectedArgs: []any{0},
+			SqlOptions:   sqlite,
+		},
+		{
+			Name:         "json_object_agg_mysql",
+			Builder:      JsonObjectAgg("key", "value"),
+			ExpectedSql:  `JSON_OBJECTAGG("key","value")`,
+			ExpectedArgs: []any(nil),
+			SqlOptions:   testutil.NewDialectOptions(driver.DialectMysql),
+		},
+		{
+			Name:         "error_filter_mysql",
+			Builder:      ArrayAgg("id").Filter(Gt("age", 18)),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `aggregate filter: unsupported in dialect "mysql"`,
+			SqlOptions:   testutil.NewDialectOptions(driver.DialectMysql),
+		},
+		{
+			Name:         "custom",
+			Builder:      Agg("PERCENTILE_DISC", driver.Value(0.5)).Filter(Eq("active", true)),
+			ExpectedSql:  `PERCENTILE_DISC(?) FILTER (WHERE "active" = ?)`,
+			ExpectedArgs: []any{0.5, true},
+		},
+		{
+			Name: "select_args_order",
+			Builder: Select(
+				"company_id",
+				As("names", StringAgg("name", ";").OrderBy(Desc(Mul("age", driver.Value(2))))),
+				As("adults", CountAll().Filter(Gte("age", 18))),
+			).From("users").Where(Eq("active", true)).GroupBy("company_id"),
+			ExpectedSql:  `SELECT "company_id",(STRING_AGG("name",? ORDER BY ("age"*?) DESC)) AS "names",(COUNT(*) FILTER (WHERE "age" >= ?)) AS "adults" FROM "users" WHERE "active" = ? GROUP BY "company_id"`,
+			ExpectedArgs: []any{";", 2, 18, true},
+		},
+		{
+			Name:         "over",
+			Builder:      Over(Agg("SUM", "total").Filter(Eq("status", "paid")), "w"),
+			ExpectedSql:  `SUM("total") FILTER (WHERE "status" = ?) OVER "w"`,
+			ExpectedArgs: []any{"paid"},
+		},
+		{
+			Name:         "error_arg",
+			Builder:      ArrayAgg("a+b"),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `target "a+b" contains illegal character '+'`,
+		},
+		{
+			Name:         "error_order",
+			Builder:      ArrayAgg("a").OrderBy(Asc("a+b")),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `target "a+b" contains illegal character '+'`,
+		},
+		{
+			Name:         "error_filter",
+			Builder:      CountAll().Filter(Eq("a+b", 1)),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `target "a+b" contains illegal character '+'`,
+		},
+	})
+}
@@ -28,7 +28,17 @@ func ArrayUnnest(arg any) driver.Sqler {
 }
 
 // Sql generates the SQL representation of the array along with any required arguments and potential errors.
+// Sqlite has no arrays and renders the array as JSON_ARRAY(...).
 func (a array) Sql(options *driver.SqlOptions) (string, []any, error) {
+	expr, err := dialectExpr("ARRAY", valSqlers(a), options)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if expr != nil {
+		return expr.Sql(options)
+	}
+
 	sql, args, err := list(a).Sql(options)
 	if err != nil {
 		return "", nil, err
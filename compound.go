@@ -0,0 +1,335 @@
+package op
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/xsqrty/op/driver"
+)
+
+// CompoundBuilder provides an interface for combining SELECT queries with UNION, INTERSECT and EXCEPT operations.
+type CompoundBuilder interface {
+	// Union appends the specified SELECT queries to the compound query using the UNION operation.
+	Union(selects ...SelectBuilder) CompoundBuilder
+	// UnionAll appends the specified SELECT queries to the compound query using the UNION ALL operation.
+	UnionAll(selects ...SelectBuilder) CompoundBuilder
+	// Intersect appends the specified SELECT queries to the compound query using the INTERSECT operation.
+	Intersect(selects ...SelectBuilder) CompoundBuilder
+	// Except appends the specified SELECT queries to the compound query using the EXCEPT operation.
+	Except(selects ...SelectBuilder) CompoundBuilder
+	// OrderBy adds one or more ordering criteria applied to the result of the whole compound query.
+	OrderBy(orders ...Order) CompoundBuilder
+	// Limit sets the maximum number of rows returned by the whole compound query.
+	Limit(limit uint64) CompoundBuilder
+	// Offset sets the number of rows to skip in the result of the whole compound query.
+	Offset(offset uint64) CompoundBuilder
+	// LimitReturningOne sets the compound query to return only one row.
+	LimitReturningOne()
+	// With returns the name of the table of the first SELECT query, used to map the compound query results.
+	With() string
+	// UsingTables returns the list of tables used to map the compound query results.
+	UsingTables() []string
+	// GetReturning returns the list of fields selected from the compound query result.
+	GetReturning() []Alias
+	// SetReturning sets the list of fields selected from the compound query result.
+	SetReturning(keys []Alias)
+	// CounterType returns the CounterType of the compound query, which is always CounterQuery.
+	CounterType() CounterType
+	// PreparedSql generates a prepared SQL statement with placeholders and arguments based on the provided SqlOptions.
+	PreparedSql(options *driver.SqlOptions) (string, []any, error)
+	// Sql generates the SQL string and associated arguments based on the provided SqlOptions.
+	Sql(options *driver.SqlOptions) (string, []any, error)
+}
+
+// compoundType defines the type of set operation used to combine SELECT queries.
+type compoundType int
+
+// compoundItem represents a single SELECT query of a compound query with the operation combining it with previous queries.
+type compoundItem struct {
+	compoundType compoundType
+	sb           SelectBuilder
+}
+
+// compoundBuilder is a structure for constructing compound SELECT queries with trailing ORDER BY, LIMIT and OFFSET.
+// If returning fields are set, the compound query is wrapped into a subquery named as the first SELECT table.
+type compoundBuilder struct {
+	items     []compoundItem
+	orders    []Order
+	returning []Alias
+	limit     uint64
+	offset    uint64
+	limitOne  bool
+}
+
+// compoundUnion represents the UNION operation.
+// compoundUnionAll represents the UNION ALL operation.
+// compoundIntersect represents the INTERSECT operation.
+// compoundExcept represents the EXCEPT operation.
+const (
+	compoundUnion compoundType = iota
+	compoundUnionAll
+	compoundIntersect
+	compoundExcept
+)
+
+var ErrNoCompoundQueries = errors.New("no queries to combine")
+
+// ensures that CompoundBuilder implements the Returnable interface at compile-time.
+var _ Returnable = CompoundBuilder(nil)
+
+// Union creates a CompoundBuilder combining the specified SELECT queries with the UNION operation.
+func Union(selects ...SelectBuilder) CompoundBuilder {
+	return (&compoundBuilder{}).Union(selects...)
+}
+
+// UnionAll creates a CompoundBuilder combining the specified SELECT queries with the UNION ALL operation.
+func UnionAll(selects ...SelectBuilder) CompoundBuilder {
+	return (&compoundBuilder{}).UnionAll(selects...)
+}
+
+// Intersect creates a CompoundBuilder combining the specified SELECT queries with the INTERSECT operation.
+func Intersect(selects ...SelectBuilder) CompoundBuilder {
+	return (&compoundBuilder{}).Intersect(selects...)
+}
+
+// Except creates a CompoundBuilder combining the specified SELECT queries with the EXCEPT operation.
+func Except(selects ...SelectBuilder) CompoundBuilder {
+	return (&compoundBuilder{}).Except(selects...)
+}
+
+// Union appends SELECT queries combined with the UNION operation and returns the updated CompoundBuilder.
+func (cb *compoundBuilder) Union(selects ...SelectBuilder) CompoundBuilder {
+	cb.add(compoundUnion, selects)
+	return cb
+}
+
+// UnionAll appends SELECT queries combined with the UNION ALL operation and returns the updated CompoundBuilder.
+func (cb *compoundBuilder) UnionAll(selects ...SelectBuilder) CompoundBuilder {
+	cb.add(compoundUnionAll, selects)
+	return cb
+}
+
+// Intersect appends SELECT queries combined with the INTERSECT operation and returns the updated CompoundBuilder.
+func (cb *compoundBuilder) Intersect(selects ...SelectBuilder) CompoundBuilder {
+	cb.add(compoundIntersect, selects)
+	return cb
+}
+
+// Except appends SELECT queries combined with the EXCEPT operation and returns the updated CompoundBuilder.
+func (cb *compoundBuilder) Except(selects ...SelectBuilder) CompoundBuilder {
+	cb.add(compoundExcept, selects)
+	return cb
+}
+
+// OrderBy appends ordering conditions applied to the whole compound query and returns the updated CompoundBuilder.
+func (cb *compoundBuilder) OrderBy(orders ...Order) CompoundBuilder {
+	cb.orders = append(cb.orders, orders...)
+	return cb
+}
+
+// Limit sets the maximum number of rows returned by the compound query and returns the updated CompoundBuilder.
+func (cb *compoundBuilder) Limit(limit uint64) CompoundBuilder {
+	cb.limit = limit
+	return cb
+}
+
+// Offset sets the number of rows skipped by the compound query and returns the updated CompoundBuilder.
+func (cb *compoundBuilder) Offset(offset uint64) CompoundBuilder {
+	cb.offset = offset
+	return cb
+}
+
+// Sql generates an SQL query string along with its arguments and any encountered error.
+// If returning fields are set, or only one row is requested, the compound query is wrapped into a subquery
+// and the ORDER BY clause is applied to the outer query.
+func (cb *compoundBuilder) Sql(options *driver.SqlOptions) (string, []any, error) {
+	if len(cb.items) == 0 {
+		return "", nil, fmt.Errorf("compound: %w", ErrNoCompoundQueries)
+	}
+
+	var buf strings.Builder
+	var args []any
+
+	wrap := len(cb.returning) > 0 || cb.limitOne
+	if wrap {
+		buf.WriteString("SELECT ")
+		if cb.limitOne && options.Dialect == driver.DialectMssql {
+			buf.WriteString("TOP 1 ")
+		}
+
+		if len(cb.returning) > 0 {
+			sqlFields, fieldsArgs, err := concatFields(cb.returning, options)
+			if err != nil {
+				return "", nil, err
+			}
+
+			args = append(args, fieldsArgs...)
+			buf.WriteString(sqlFields)
+		} else {
+			buf.WriteByte('*')
+		}
+
+		buf.WriteString(" FROM (")
+	}
+
+	for i := range cb.items {
+		if i > 0 {
+			buf.WriteString(" " + cb.items[i].compoundType.String() + " ")
+		}
+
+		sql, itemArgs, err := cb.items[i].Sql(i, options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		args = append(args, itemArgs...)
+		buf.WriteString(sql)
+	}
+
+	paged := cb.limit > 0 || cb.offset > 0
+	if len(cb.orders) > 0 && (!wrap || paged) {
+		sql, ordersArgs, err := concatFields(cb.orders, options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		args = append(args, ordersArgs...)
+		buf.WriteString(" ORDER BY ")
+		buf.WriteString(sql)
+	}
+
+	sql, pagingArgs := pagingSql(cb.limit, cb.offset, len(cb.orders) > 0, options)
+	args = append(args, pagingArgs...)
+	buf.WriteString(sql)
+
+	if wrap {
+		buf.WriteString(") AS ")
+		sqlName, nameArgs, err := Column(cb.With()).Sql(options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		args = append(args, nameArgs...)
+		buf.WriteString(sqlName)
+
+		// the order of the derived table isn't guaranteed to be kept by the outer query
+		if len(cb.orders) > 0 {
+			sql, ordersArgs, err := concatFields(cb.orders, options)
+			if err != nil {
+				return "", nil, err
+			}
+
+			args = append(args, ordersArgs...)
+			buf.WriteString(" ORDER BY ")
+			buf.WriteString(sql)
+		}
+
+		if cb.limitOne && options.Dialect != driver.DialectMssql {
+			sql, limitArgs, err := driver.Pure(" LIMIT ?", uint64(1)).Sql(options)
+			if err != nil {
+				return "", nil, err
+			}
+
+			args = append(args, limitArgs...)
+			buf.WriteString(sql)
+		}
+	}
+
+	return buf.String(), args, nil
+}
+
+// Sql generates the SELECT query of the compound query with the given position.
+// The query with its own ORDER BY, LIMIT or OFFSET is parenthesized, because the trailing clauses of the last query
+// apply to the whole compound query. Sqlite and SQL Server don't accept parenthesized queries and use a derived table instead.
+func (ci compoundItem) Sql(position int, options *driver.SqlOptions) (string, []any, error) {
+	sql, args, err := ci.sb.Sql(options)
+	if err != nil {
+		return "", nil, err
+	}
+
+	sb, ok := ci.sb.(*selectBuilder)
+	if !ok || (len(sb.orders) == 0 && sb.limit == 0 && sb.offset == 0) {
+		return sql, args, nil
+	}
+
+	switch options.Dialect {
+	case driver.DialectSqlite, driver.DialectMssql:
+		// SQL Server allows ORDER BY in a derived table only with OFFSET
+		if options.Dialect == driver.DialectMssql && sb.limit == 0 && sb.offset == 0 {
+			sql += " OFFSET 0 ROWS"
+		}
+
+		sqlName, nameArgs, err := Column("op_compound_" + strconv.Itoa(position+1)).Sql(options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		return "SELECT * FROM (" + sql + ") AS " + sqlName, append(args, nameArgs...), nil
+	}
+
+	return "(" + sql + ")", args, nil
+}
+
+// PreparedSql generates a parameterized SQL string and corresponding arguments based on the provided SqlOptions.
+func (cb *compoundBuilder) PreparedSql(options *driver.SqlOptions) (string, []any, error) {
+	return driver.Sql(cb, options)
+}
+
+// LimitReturningOne sets the compound query to return only one row, without changing the limit of the compound query.
+func (cb *compoundBuilder) LimitReturningOne() {
+	cb.limitOne = true
+}
+
+// With returns the table name of the first SELECT query, used as the name of the wrapped compound query.
+func (cb *compoundBuilder) With() string {
+	if len(cb.items) == 0 {
+		return ""
+	}
+
+	return cb.items[0].sb.With()
+}
+
+// UsingTables returns a list containing the name of the wrapped compound query.
+func (cb *compoundBuilder) UsingTables() []string {
+	return []string{cb.With()}
+}
+
+// GetReturning retrieves the list of fields selected from the compound query result.
+func (cb *compoundBuilder) GetReturning() []Alias {
+	return cb.returning
+}
+
+// SetReturning sets the list of fields selected from the compound query result.
+func (cb *compoundBuilder) SetReturning(keys []Alias) {
+	cb.returning = keys
+}
+
+// CounterType returns the CounterType associated with the compoundBuilder, indicating a query operation.
+func (cb *compoundBuilder) CounterType() CounterType {
+	return CounterQuery
+}
+
+// add appends non-nil SELECT queries to the compound query with the specified operation.
+func (cb *compoundBuilder) add(typ compoundType, selects []SelectBuilder) {
+	for _, sb := range selects {
+		if sb != nil {
+			cb.items = append(cb.items, compoundItem{compoundType: typ, sb: sb})
+		}
+	}
+}
+
+// String returns the SQL representation of the compoundType, such as "UNION" or "EXCEPT".
+func (c compoundType) String() string {
+	switch c {
+	case compoundUnionAll:
+		return "UNION ALL"
+	case compoundIntersect:
+		return "INTERSECT"
+	case compoundExcept:
+		return "EXCEPT"
+	}
+
+	return "UNION"
+}
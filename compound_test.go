@@ -0,0 +1,160 @@
+package op
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xsqrty/op/driver"
+	"github.com/xsqrty/op/internal/testutil"
+)
+
+func TestCompound(t *testing.T) {
+	t.Parallel()
+	testutil.RunCases(t, options, []testutil.TestCase{
+		{
+			Name: "union",
+			Builder: Union(
+				Select("id").From("users").Where(Eq("active", true)),
+				Select("id").From("archived_users").Where(Eq("active", false)),
+			),
+			ExpectedSql:  `SELECT "id" FROM "users" WHERE "active" = ? UNION SELECT "id" FROM "archived_users" WHERE "active" = ?`,
+			ExpectedArgs: []any{true, false},
+		},
+		{
+			Name:         "union_all",
+			Builder:      UnionAll(Select("id").From("a"), Select("id").From("b"), Select("id").From("c")),
+			ExpectedSql:  `SELECT "id" FROM "a" UNION ALL SELECT "id" FROM "b" UNION ALL SELECT "id" FROM "c"`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "intersect",
+			Builder:      Intersect(Select("id").From("a"), Select("id").From("b")),
+			ExpectedSql:  `SELECT "id" FROM "a" INTERSECT SELECT "id" FROM "b"`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "except",
+			Builder:      Except(Select("id").From("a"), Select("id").From("b")),
+			ExpectedSql:  `SELECT "id" FROM "a" EXCEPT SELECT "id" FROM "b"`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name: "chain",
+			Builder: Union(Select("id").From("a")).
+				UnionAll(Select("id").From("b")).
+				Intersect(Select("id").From("c")).
+				Except(Select("id").From("d")),
+			ExpectedSql:  `SELECT "id" FROM "a" UNION ALL SELECT "id" FROM "b" INTERSECT SELECT "id" FROM "c" EXCEPT SELECT "id" FROM "d"`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name: "order_limit_offset",
+			Builder: Union(Select("id").From("a").Where(Eq("id", 1)), Select("id").From("b")).
+				OrderBy(Desc("id")).
+				Limit(10).
+				Offset(20),
+			ExpectedSql:  `SELECT "id" FROM "a" WHERE "id" = ? UNION SELECT "id" FROM "b" ORDER BY "id" DESC LIMIT ? OFFSET ?`,
+			ExpectedArgs: []any{1, uint64(10), uint64(20)},
+		},
+		{
+			Name: "member_order_limit",
+			Builder: Union(
+				Select("a").From("t").OrderBy(Asc("a")).Limit(2),
+				Select("a").From("u"),
+			),
+			ExpectedSql:  `(SELECT "a" FROM "t" ORDER BY "a" ASC LIMIT ?) UNION SELECT "a" FROM "u"`,
+			ExpectedArgs: []any{uint64(2)},
+		},
+		{
+			Name: "member_order_limit_sqlite",
+			Builder: Union(
+				Select("a").From("t"),
+				Select("a").From("u").OrderBy(Asc("a")).Limit(2),
+			),
+			SqlOptions:   testutil.NewDialectOptions(driver.DialectSqlite),
+			ExpectedSql:  `SELECT "a" FROM "t" UNION SELECT * FROM (SELECT "a" FROM "u" ORDER BY "a" ASC LIMIT ?) AS "op_compound_2"`,
+			ExpectedArgs: []any{uint64(2)},
+		},
+		{
+			Name: "member_order_mssql",
+			Builder: Union(
+				Select("a").From("t").OrderBy(Asc("a")),
+				Select("a").From("u"),
+			),
+			SqlOptions:   testutil.NewDialectOptions(driver.DialectMssql),
+			ExpectedSql:  `SELECT * FROM (SELECT "a" FROM "t" ORDER BY "a" ASC OFFSET 0 ROWS) AS "op_compound_1" UNION SELECT "a" FROM "u"`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "nil_select",
+			Builder:      Union(Select("id").From("a"), nil),
+			ExpectedSql:  `SELECT "id" FROM "a"`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "error_empty",
+			Builder:      Union(),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  "compound: no queries to combine",
+		},
+		{
+			Name:         "error_select",
+			Builder:      Union(Select("id").From("a"), Select("id").From("a+b")),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `target "a+b" contains illegal character '+'`,
+		},
+		{
+			Name:         "error_order",
+			Builder:      Union(Select("id").From("a")).OrderBy(Asc("a+b")),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `target "a+b" contains illegal character '+'`,
+		},
+	})
+}
+
+func TestCompoundReturning(t *testing.T) {
+	t.Parallel()
+	item := Union(Select("id").From("users"), Select("id").From("archived_users")).OrderBy(Asc("id")).Limit(5)
+
+	require.Equal(t, "users", item.With())
+	require.Equal(t, []string{"users"}, item.UsingTables())
+	require.Equal(t, CounterQuery, item.CounterType())
+	require.Nil(t, item.GetReturning())
+
+	item.SetReturning([]Alias{ColumnAlias("users.id")})
+	item.LimitReturningOne()
+	require.Equal(t, []Alias{ColumnAlias("users.id")}, item.GetReturning())
+
+	sql, args, err := item.PreparedSql(testutil.NewDefaultOptions())
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		`SELECT "users"."id" FROM (SELECT "id" FROM "users" UNION SELECT "id" FROM "archived_users" ORDER BY "id" ASC LIMIT ?) AS "users" ORDER BY "id" ASC LIMIT ?`,
+		sql,
+	)
+	require.Equal(t, []any{uint64(5), uint64(1)}, args)
+
+	sql, args, err = Union().PreparedSql(testutil.NewDefaultOptions())
+	require.EqualError(t, err, "compound: no queries to combine")
+	require.Equal(t, "", sql)
+	require.Nil(t, args)
+	require.Equal(t, "", Union().With())
+}
+
+func TestCompoundLimitOne(t *testing.T) {
+	t.Parallel()
+	item := Except(Select("id").From("users"), Select("id").From("archived_users")).OrderBy(Desc("id"))
+	item.LimitReturningOne()
+
+	sql, args, err := item.Sql(options)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		`SELECT * FROM (SELECT "id" FROM "users" EXCEPT SELECT "id" FROM "archived_users") AS "users" ORDER BY "id" DESC LIMIT ?`,
+		sql,
+	)
+	require.Equal(t, []any{uint64(1)}, args)
+}
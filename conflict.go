@@ -1,32 +1,225 @@
 package op
 
-import "github.com/xsqrty/op/driver"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xsqrty/op/driver"
+)
+
+// ConflictTarget defines an interface for the conflict target of the "ON CONFLICT" clause,
+// such as a list of unique index columns or a named constraint.
+type ConflictTarget interface {
+	// Where adds a predicate to the conflict target, used to infer partial unique indexes.
+	Where(exp driver.Sqler) ConflictTarget
+	// Sql generates the conflict target as an SQL string with its arguments based on the provided SqlOptions.
+	Sql(options *driver.SqlOptions) (string, []any, error)
+}
 
 // conflict represents a definition for handling SQL "ON CONFLICT" clauses, containing the target and the action to perform.
 type conflict struct {
-	target Alias
+	target ConflictTarget
 	expr   driver.Sqler
 }
 
+// conflictTarget represents the conflict target with the index columns or the constraint name, and the index predicate.
+type conflictTarget struct {
+	columns    []driver.Sqler
+	constraint Column
+	where      And
+	err        error
+}
+
+// doNothing represents the "DO NOTHING" action of the "ON CONFLICT" clause.
+type doNothing struct{}
+
 // Excluded is a type alias for Column, representing a reference to the special SQL `EXCLUDED` table for upsert operations.
 type Excluded Column
 
+// ConflictColumns creates a ConflictTarget inferring the unique index by the specified columns or expressions.
+// Columns must be strings or objects implementing Sqler.
+func ConflictColumns(columns ...any) ConflictTarget {
+	ct := &conflictTarget{}
+	for i := range columns {
+		switch val := columns[i].(type) {
+		case string:
+			ct.columns = append(ct.columns, Column(val))
+		case driver.Sqler:
+			ct.columns = append(ct.columns, val)
+		default:
+			ct.err = fmt.Errorf("%w: %T must be a string or driver.Sqler", ErrUnsupportedType, columns[i])
+			return ct
+		}
+	}
+
+	return ct
+}
+
+// ConflictConstraint creates a ConflictTarget referencing the constraint by name with the `ON CONSTRAINT` clause.
+func ConflictConstraint(name string) ConflictTarget {
+	return &conflictTarget{constraint: Column(name)}
+}
+
 // DoNothing returns a driver.Sqler that generates the SQL expression "NOTHING".
+// MySQL renders the insert statement as "INSERT IGNORE" instead.
 func DoNothing() driver.Sqler {
-	return driver.Pure("NOTHING")
+	return doNothing{}
 }
 
 // DoUpdate constructs an UpdateBuilder to define an SQL UPDATE statement using the provided update fields and values.
+// The WHERE clause of the UpdateBuilder restricts the rows to be updated.
 func DoUpdate(updates Updates) UpdateBuilder {
 	return Update(nil, updates)
 }
 
+// Where adds a predicate to the conflict target and returns the updated ConflictTarget.
+func (ct *conflictTarget) Where(exp driver.Sqler) ConflictTarget {
+	if exp != nil {
+		ct.where = append(ct.where, exp)
+	}
+
+	return ct
+}
+
+// Sql generates the conflict target, such as `("tenant_id","email") WHERE ...` or `ON CONSTRAINT "users_email_key"`.
+func (ct *conflictTarget) Sql(options *driver.SqlOptions) (string, []any, error) {
+	if ct.err != nil {
+		return "", nil, ct.err
+	}
+
+	if !ct.constraint.IsZero() {
+		if options.Dialect == driver.DialectSqlite {
+			return "", nil, fmt.Errorf("on conflict on constraint: %w %q", ErrUnsupportedDialect, options.Dialect)
+		}
+
+		if len(ct.where) > 0 {
+			return "", nil, fmt.Errorf("on conflict on constraint %q can't have a WHERE predicate", ct.constraint)
+		}
+
+		sql, args, err := ct.constraint.Sql(options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		return "ON CONSTRAINT " + sql, args, nil
+	}
+
+	if len(ct.columns) == 0 {
+		return "", nil, fmt.Errorf("on conflict: %w", ErrFieldsEmpty)
+	}
+
+	var buf strings.Builder
+	buf.WriteByte('(')
+	sql, args, err := concatFields(ct.columns, options)
+	if err != nil {
+		return "", nil, err
+	}
+
+	buf.WriteString(sql)
+	buf.WriteByte(')')
+
+	if len(ct.where) > 0 {
+		buf.WriteString(" WHERE ")
+		sqlWhere, whereArgs, err := ct.where.Sql(options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		args = append(args, whereArgs...)
+		buf.WriteString(sqlWhere)
+	}
+
+	return buf.String(), args, nil
+}
+
+// Sql generates the "ON CONFLICT" clause with the optional conflict target and the action to perform.
+func (c *conflict) Sql(options *driver.SqlOptions) (string, []any, error) {
+	if c.expr == nil {
+		return "", nil, fmt.Errorf("ON CONFLICT clause requires an action")
+	}
+
+	switch options.Dialect {
+	case driver.DialectMysql:
+		return c.duplicateKeySql(options)
+	case driver.DialectMssql:
+		return "", nil, fmt.Errorf("on conflict: %w %q", ErrUnsupportedDialect, options.Dialect)
+	}
+
+	var buf strings.Builder
+	var args []any
+
+	buf.WriteString("ON CONFLICT ")
+	if c.target != nil {
+		sqlTar, tarArgs, err := c.target.Sql(options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		args = append(args, tarArgs...)
+		buf.WriteString(sqlTar)
+		buf.WriteByte(' ')
+	}
+
+	buf.WriteString("DO ")
+	sqlExp, expArgs, err := c.expr.Sql(options)
+	if err != nil {
+		return "", nil, err
+	}
+
+	args = append(args, expArgs...)
+	buf.WriteString(sqlExp)
+
+	return buf.String(), args, nil
+}
+
+// duplicateKeySql generates the MySQL "ON DUPLICATE KEY UPDATE" clause equivalent to the "ON CONFLICT" clause.
+// MySQL checks all unique indexes, so the conflict target columns are not rendered.
+// The "DO NOTHING" action is rendered as "INSERT IGNORE" by the insert builder.
+func (c *conflict) duplicateKeySql(options *driver.SqlOptions) (string, []any, error) {
+	if ct, ok := c.target.(*conflictTarget); ok && len(ct.where) > 0 {
+		return "", nil, fmt.Errorf("on conflict target predicate: %w %q", ErrUnsupportedDialect, options.Dialect)
+	}
+
+	var sql string
+	var args []any
+	var err error
+
+	switch expr := c.expr.(type) {
+	case doNothing:
+		return "", nil, nil
+	case *updateBuilder:
+		if len(expr.where) > 0 {
+			return "", nil, fmt.Errorf("on conflict do update where: %w %q", ErrUnsupportedDialect, options.Dialect)
+		}
+
+		sql, args, err = concatUpdates(expr.updatesKeys, expr.updatesVals, options)
+	default:
+		sql, args, err = expr.Sql(options)
+	}
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	return "ON DUPLICATE KEY UPDATE " + sql, args, nil
+}
+
+// Sql generates the "NOTHING" keyword of the "DO NOTHING" conflict action.
+func (doNothing) Sql(_ *driver.SqlOptions) (string, []any, error) {
+	return "NOTHING", nil, nil
+}
+
 // Sql generates a SQL string prefixed with "EXCLUDED." using the given SqlOptions, returning the string, arguments, and error.
+// MySQL references the inserted value with "VALUES(column)".
 func (ex Excluded) Sql(options *driver.SqlOptions) (string, []any, error) {
 	sql, args, err := Column(ex).Sql(options)
 	if err != nil {
 		return "", nil, err
 	}
 
+	if options.Dialect == driver.DialectMysql {
+		return "VALUES(" + sql + ")", args, nil
+	}
+
 	return "EXCLUDED." + sql, args, nil
 }
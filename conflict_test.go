@@ -3,9 +3,58 @@ package op
 import (
 	"testing"
 
+	"github.com/xsqrty/op/driver"
 	"github.com/xsqrty/op/internal/testutil"
 )
 
+func TestConflictMysql(t *testing.T) {
+	t.Parallel()
+	testutil.RunCases(t, testutil.NewDialectOptions(driver.DialectMysql), []testutil.TestCase{
+		{
+			Name:         "do_update",
+			Builder:      Insert("users", Inserting{"email": "a@b.c"}).OnConflict("email", DoUpdate(Updates{"email": Excluded("email")})),
+			ExpectedSql:  `INSERT INTO "users" ("email") VALUES (?) ON DUPLICATE KEY UPDATE "email"=VALUES("email")`,
+			ExpectedArgs: []any{"a@b.c"},
+		},
+		{
+			Name: "do_update_args",
+			Builder: Insert("users", Inserting{"email": "a@b.c"}).
+				OnConflict("email", DoUpdate(Updates{"visits": Add("visits", driver.Value(1))})),
+			ExpectedSql:  `INSERT INTO "users" ("email") VALUES (?) ON DUPLICATE KEY UPDATE "visits"=("visits"+?)`,
+			ExpectedArgs: []any{"a@b.c", 1},
+		},
+		{
+			Name:         "do_nothing",
+			Builder:      Insert("users", Inserting{"email": "a@b.c"}).OnConflict(ConflictColumns("email"), DoNothing()),
+			ExpectedSql:  `INSERT IGNORE INTO "users" ("email") VALUES (?)`,
+			ExpectedArgs: []any{"a@b.c"},
+		},
+		{
+			Name: "error_target_where",
+			Builder: Insert("users", Inserting{"email": "a@b.c"}).
+				OnConflict(ConflictColumns("email").Where(Eq("deleted", false)), DoNothing()),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `on conflict target predicate: unsupported in dialect "mysql"`,
+		},
+		{
+			Name: "error_update_where",
+			Builder: Insert("users", Inserting{"email": "a@b.c"}).
+				OnConflict("email", DoUpdate(Updates{"email": Excluded("email")}).Where(Eq("deleted", false))),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `on conflict do update where: unsupported in dialect "mysql"`,
+		},
+		{
+			Name:         "error_returning",
+			Builder:      Insert("users", Inserting{"email": "a@b.c"}).Returning("id"),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `returning: unsupported in dialect "mysql"`,
+		},
+	})
+}
+
 func TestConflict(t *testing.T) {
 	t.Parallel()
 	testutil.RunCases(t, options, []testutil.TestCase{
@@ -40,5 +89,107 @@ func TestConflict(t *testing.T) {
 				`UPDATE SET "Name"=EXCLUDED."Name","Id"=EXCLUDED."Id"`,
 			},
 		},
+		{
+			Name: "composite_target",
+			Builder: Insert("users", Inserting{"email": "a@b.c"}).
+				OnConflict([]string{"tenant_id", "email"}, DoNothing()),
+			ExpectedSql:  `INSERT INTO "users" ("email") VALUES (?) ON CONFLICT ("tenant_id","email") DO NOTHING`,
+			ExpectedArgs: []any{"a@b.c"},
+		},
+		{
+			Name: "conflict_columns",
+			Builder: Insert("users", Inserting{"email": "a@b.c"}).
+				OnConflict(ConflictColumns("tenant_id", Lower("email")), DoNothing()),
+			ExpectedSql:  `INSERT INTO "users" ("email") VALUES (?) ON CONFLICT ("tenant_id",LOWER("email")) DO NOTHING`,
+			ExpectedArgs: []any{"a@b.c"},
+		},
+		{
+			Name: "partial_index_target",
+			Builder: Insert("users", Inserting{"email": "a@b.c"}).
+				OnConflict(ConflictColumns("email").Where(Eq("deleted", false)), DoNothing()),
+			ExpectedSql:  `INSERT INTO "users" ("email") VALUES (?) ON CONFLICT ("email") WHERE "deleted" = ? DO NOTHING`,
+			ExpectedArgs: []any{"a@b.c", false},
+		},
+		{
+			Name: "constraint_target",
+			Builder: Insert("users", Inserting{"email": "a@b.c"}).
+				OnConflict(ConflictConstraint("users_email_key"), DoNothing()),
+			ExpectedSql:  `INSERT INTO "users" ("email") VALUES (?) ON CONFLICT ON CONSTRAINT "users_email_key" DO NOTHING`,
+			ExpectedArgs: []any{"a@b.c"},
+		},
+		{
+			Name:         "without_target",
+			Builder:      Insert("users", Inserting{"email": "a@b.c"}).OnConflict(nil, DoNothing()),
+			ExpectedSql:  `INSERT INTO "users" ("email") VALUES (?) ON CONFLICT DO NOTHING`,
+			ExpectedArgs: []any{"a@b.c"},
+		},
+		{
+			Name: "do_update_where",
+			Builder: Insert("users", Inserting{"email": "a@b.c"}).
+				OnConflict(
+					ConflictColumns("tenant_id", "email").Where(Eq("deleted", false)),
+					DoUpdate(Updates{"updated_at": Excluded("updated_at")}).Where(Lt("users.updated_at", Excluded("updated_at"))),
+				).
+				Returning("id"),
+			ExpectedSql:  `INSERT INTO "users" ("email") VALUES (?) ON CONFLICT ("tenant_id","email") WHERE "deleted" = ? DO UPDATE SET "updated_at"=EXCLUDED."updated_at" WHERE "users"."updated_at" < EXCLUDED."updated_at" RETURNING "id"`,
+			ExpectedArgs: []any{"a@b.c", false},
+		},
+		{
+			Name: "do_update_where_sqlite",
+			Builder: Insert("users", Inserting{"email": "a@b.c"}).
+				OnConflict(
+					ConflictColumns("tenant_id", "email").Where(Eq("deleted", false)),
+					DoUpdate(Updates{"name": Excluded("name")}).Where(Ne("name", Excluded("name"))),
+				),
+			SqlOptions:   testutil.NewDialectOptions(driver.DialectSqlite),
+			ExpectedSql:  `INSERT INTO "users" ("email") VALUES (?) ON CONFLICT ("tenant_id","email") WHERE "deleted" = ? DO UPDATE SET "name"=EXCLUDED."name" WHERE "name" != EXCLUDED."name"`,
+			ExpectedArgs: []any{"a@b.c", false},
+		},
+		{
+			Name: "error_constraint_sqlite",
+			Builder: Insert("users", Inserting{"email": "a@b.c"}).
+				OnConflict(ConflictConstraint("users_email_key"), DoNothing()),
+			SqlOptions:   testutil.NewDialectOptions(driver.DialectSqlite),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `on conflict on constraint: unsupported in dialect "sqlite"`,
+		},
+		{
+			Name: "error_constraint_where",
+			Builder: Insert("users", Inserting{"email": "a@b.c"}).
+				OnConflict(ConflictConstraint("users_email_key").Where(Eq("deleted", false)), DoNothing()),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `on conflict on constraint "users_email_key" can't have a WHERE predicate`,
+		},
+		{
+			Name:         "error_columns_type",
+			Builder:      Insert("users", Inserting{"email": "a@b.c"}).OnConflict(ConflictColumns(1), DoNothing()),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  "unknown type: int must be a string or driver.Sqler",
+		},
+		{
+			Name:         "error_columns_empty",
+			Builder:      Insert("users", Inserting{"email": "a@b.c"}).OnConflict(ConflictColumns(), DoNothing()),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  "on conflict: fields is empty",
+		},
+		{
+			Name: "error_where",
+			Builder: Insert("users", Inserting{"email": "a@b.c"}).
+				OnConflict(ConflictColumns("email").Where(Eq("a+b", 1)), DoNothing()),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `target "a+b" contains illegal character '+'`,
+		},
+		{
+			Name:         "error_action",
+			Builder:      Insert("users", Inserting{"email": "a@b.c"}).OnConflict("email", nil),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  "ON CONFLICT clause requires an action",
+		},
 	})
 }
@@ -0,0 +1,93 @@
+package op
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/xsqrty/op/driver"
+)
+
+// cte represents a single common table expression of the WITH clause, including its name, columns, and query.
+type cte struct {
+	name    Column
+	columns []Column
+	query   driver.Sqler
+}
+
+// ctes represents a WITH clause, containing an ordered list of common table expressions and the RECURSIVE modifier.
+type ctes struct {
+	recursive bool
+	items     []cte
+}
+
+var ErrCteQueryEmpty = errors.New("cte query is empty")
+
+// add appends a new common table expression with the given name, query, and optional column list.
+// If recursive is true, the whole WITH clause is marked as RECURSIVE.
+func (c *ctes) add(name string, query driver.Sqler, columns []string, recursive bool) {
+	item := cte{name: Column(name), query: query}
+	if len(columns) > 0 {
+		item.columns = make([]Column, len(columns))
+		for i, col := range columns {
+			item.columns[i] = Column(col)
+		}
+	}
+
+	c.recursive = c.recursive || recursive
+	c.items = append(c.items, item)
+}
+
+// Sql generates the WITH clause as an SQL string, combining all common table expressions in the order of declaration.
+func (c *ctes) Sql(options *driver.SqlOptions) (string, []any, error) {
+	var buf strings.Builder
+	var args []any
+
+	buf.WriteString("WITH ")
+	// SQL Server detects the recursive common table expressions without the keyword
+	if c.recursive && options.Dialect != driver.DialectMssql {
+		buf.WriteString("RECURSIVE ")
+	}
+
+	for i := range c.items {
+		if c.items[i].query == nil {
+			return "", nil, fmt.Errorf("cte %q: %w", c.items[i].name, ErrCteQueryEmpty)
+		}
+
+		sqlName, nameArgs, err := c.items[i].name.Sql(options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		args = append(args, nameArgs...)
+		buf.WriteString(sqlName)
+
+		if len(c.items[i].columns) > 0 {
+			sqlColumns, columnsArgs, err := concatFields(c.items[i].columns, options)
+			if err != nil {
+				return "", nil, err
+			}
+
+			args = append(args, columnsArgs...)
+			buf.WriteString(" (")
+			buf.WriteString(sqlColumns)
+			buf.WriteByte(')')
+		}
+
+		buf.WriteString(" AS (")
+		sqlQuery, queryArgs, err := c.items[i].query.Sql(options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		args = append(args, queryArgs...)
+		buf.WriteString(sqlQuery)
+		buf.WriteByte(')')
+
+		if i != len(c.items)-1 {
+			buf.WriteByte(options.FieldsDelim)
+		}
+	}
+
+	return buf.String(), args, nil
+}
@@ -0,0 +1,119 @@
+package op
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xsqrty/op/driver"
+	"github.com/xsqrty/op/internal/testutil"
+)
+
+func TestCte(t *testing.T) {
+	t.Parallel()
+	testutil.RunCases(t, options, []testutil.TestCase{
+		{
+			Name: "select_cte",
+			Builder: Select("id").
+				Cte("active", Select("id").From("users").Where(Eq("active", true))).
+				From("active"),
+			ExpectedSql:  `WITH "active" AS (SELECT "id" FROM "users" WHERE "active" = ?) SELECT "id" FROM "active"`,
+			ExpectedArgs: []any{true},
+		},
+		{
+			Name: "select_cte_many",
+			Builder: Select().
+				Cte("a", Select().From("users").Where(Eq("id", 1))).
+				Cte("b", Select().From("a").Where(Eq("id", 2)), "user_id").
+				From("b").
+				Where(Eq("user_id", 3)),
+			ExpectedSql:  `WITH "a" AS (SELECT * FROM "users" WHERE "id" = ?),"b" ("user_id") AS (SELECT * FROM "a" WHERE "id" = ?) SELECT * FROM "b" WHERE "user_id" = ?`,
+			ExpectedArgs: []any{1, 2, 3},
+		},
+		{
+			Name: "select_cte_recursive",
+			Builder: Select("n").
+				CteRecursive("t", driver.Pure("SELECT 1 UNION ALL SELECT n+1 FROM t WHERE n < ?", 5), "n").
+				From("t"),
+			ExpectedSql:  `WITH RECURSIVE "t" ("n") AS (SELECT 1 UNION ALL SELECT n+1 FROM t WHERE n < ?) SELECT "n" FROM "t"`,
+			ExpectedArgs: []any{5},
+		},
+		{
+			Name: "select_cte_recursive_union",
+			Builder: Select("id", "parent_id").
+				CteRecursive(
+					"tree",
+					UnionAll(
+						Select("id", "parent_id").From("categories").Where(Eq("id", 1)),
+						Select("categories.id", "categories.parent_id").
+							From("categories").
+							Join("tree", Eq("categories.parent_id", Column("tree.id"))),
+					),
+					"id",
+					"parent_id",
+				).
+				From("tree"),
+			ExpectedSql:  `WITH RECURSIVE "tree" ("id","parent_id") AS (SELECT "id","parent_id" FROM "categories" WHERE "id" = ? UNION ALL SELECT "categories"."id","categories"."parent_id" FROM "categories" JOIN "tree" ON "categories"."parent_id" = "tree"."id") SELECT "id","parent_id" FROM "tree"`,
+			ExpectedArgs: []any{1},
+		},
+		{
+			Name: "insert_cte",
+			Builder: Insert("archive", Inserting{"id": Column("old.id")}).
+				Cte("old", Select("id").From("users").Where(Lt("id", 10))),
+			ExpectedSql:  `WITH "old" AS (SELECT "id" FROM "users" WHERE "id" < ?) INSERT INTO "archive" ("id") VALUES ("old"."id")`,
+			ExpectedArgs: []any{10},
+		},
+		{
+			Name: "update_cte",
+			Builder: Update("users", Updates{"active": false}).
+				Cte("old", Select("id").From("users").Where(Lt("id", 10))).
+				Where(In("id", Select("id").From("old"))),
+			ExpectedSql:  `WITH "old" AS (SELECT "id" FROM "users" WHERE "id" < ?) UPDATE "users" SET "active"=? WHERE "id" IN (SELECT "id" FROM "old")`,
+			ExpectedArgs: []any{10, false},
+		},
+		{
+			Name: "delete_cte",
+			Builder: Delete("users").
+				CteRecursive("old", Select("id").From("users").Where(Lt("id", 10))).
+				Where(In("id", Select("id").From("old"))).
+				Returning("id"),
+			ExpectedSql:  `WITH RECURSIVE "old" AS (SELECT "id" FROM "users" WHERE "id" < ?) DELETE FROM "users" WHERE "id" IN (SELECT "id" FROM "old") RETURNING "id"`,
+			ExpectedArgs: []any{10},
+		},
+		{
+			Name:         "error_cte_empty",
+			Builder:      Select().Cte("empty", nil).From("empty"),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `cte "empty": cte query is empty`,
+		},
+		{
+			Name:         "error_cte_name",
+			Builder:      Select().Cte("a+b", Select().From("users")).From("users"),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `target "a+b" contains illegal character '+'`,
+		},
+		{
+			Name:         "error_cte_columns",
+			Builder:      Delete("users").Cte("a", Select().From("users"), "a+b"),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `target "a+b" contains illegal character '+'`,
+		},
+		{
+			Name:         "error_cte_query",
+			Builder:      Update("users", Updates{"a": 1}).Cte("a", Select().From("a+b")),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `target "a+b" contains illegal character '+'`,
+		},
+	})
+}
+
+func TestCteUsingTables(t *testing.T) {
+	t.Parallel()
+	item := Select().Cte("tree", Select().From("categories")).From("tree")
+
+	require.Equal(t, []string{"tree"}, item.UsingTables())
+	require.Equal(t, "tree", item.With())
+}
@@ -3,9 +3,11 @@ package db
 import (
 	"context"
 	"database/sql"
+	sqldriver "database/sql/driver"
 	"errors"
 	"io"
 	"iter"
+	"strconv"
 
 	"github.com/xsqrty/op/driver"
 )
@@ -19,7 +21,7 @@ type ConnPool interface {
 
 // Transacter defines an interface for handling transactional operations.
 type Transacter interface {
-	Transact(ctx context.Context, handler func(ctx context.Context) error) error
+	Transact(ctx context.Context, handler func(ctx context.Context) error, opts ...TxOption) error
 }
 
 // stdDb describes an interface of the standard library.
@@ -29,16 +31,39 @@ type stdDb interface {
 	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
 }
 
+// txBeginner describes the types of the standard library starting transactions, such as sql.DB and sql.Conn.
+type txBeginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+// ConnPoolOption defines a function type for configuring the ConnPool created by NewConnPool.
+type ConnPoolOption func(cp *connPool)
+
 // connPool implementation of ConnPool for standard sql.DB
 type connPool struct {
-	stdDb   *sql.DB
-	options *driver.SqlOptions
+	stdDb     *sql.DB
+	options   *driver.SqlOptions
+	retryable func(err error) bool
+	classify  ErrorClassifier
 }
 
 // rowsResult implementation of Rows for sql.Rows
 type rowsResult struct {
-	rows *sql.Rows
-	err  error
+	rows     *sql.Rows
+	err      error
+	classify ErrorClassifier
+}
+
+// rowResult implementation of Row for sql.Row
+type rowResult struct {
+	row      *sql.Row
+	classify ErrorClassifier
+}
+
+// txState represents the transaction stored in the context with the nesting depth of its savepoints.
+type txState struct {
+	tx    *sql.Tx
+	depth int
 }
 
 // txType represents a custom string type used to define specific transaction types or keys within the application.
@@ -47,12 +72,30 @@ type txType string
 // txKey is a predefined key of type txType used to store and retrieve transaction objects within a context.
 var txKey = txType("tx")
 
-// txOptions specifies transaction options with Read Committed isolation level for database transactions.
-var txOptions = &sql.TxOptions{Isolation: sql.LevelReadCommitted}
-
 // NewConnPool create ConnPool based on sql.DB
-func NewConnPool(db *sql.DB, options *driver.SqlOptions) ConnPool {
-	return &connPool{stdDb: db, options: options}
+func NewConnPool(db *sql.DB, options *driver.SqlOptions, opts ...ConnPoolOption) ConnPool {
+	cp := &connPool{stdDb: db, options: options}
+	for _, opt := range opts {
+		opt(cp)
+	}
+
+	return cp
+}
+
+// WithRetryable sets the function reporting whether the error of a transaction is retryable by the RetryPolicy,
+// such as a serialization failure or a deadlock of the driver.
+func WithRetryable(retryable func(err error) bool) ConnPoolOption {
+	return func(cp *connPool) {
+		cp.retryable = retryable
+	}
+}
+
+// WithErrorClassifier sets the function classifying the driver errors returned by the connection pool,
+// which makes them match the sentinel errors such as ErrUniqueViolation.
+func WithErrorClassifier(classify ErrorClassifier) ConnPoolOption {
+	return func(cp *connPool) {
+		cp.classify = classify
+	}
 }
 
 // Exec executes a SQL query that doesn't return rows, such as an INSERT, UPDATE, or DELETE statement.
@@ -60,37 +103,82 @@ func NewConnPool(db *sql.DB, options *driver.SqlOptions) ConnPool {
 // The SQL statement can include placeholders for arguments, which are provided via the variadic args parameter.
 // Returns an ExecResult that contains methods for retrieving the number of affected rows and last inserted ID.
 func (cp *connPool) Exec(ctx context.Context, sql string, args ...any) (ExecResult, error) {
-	return cp.get(ctx).ExecContext(ctx, sql, args...)
+	res, err := cp.get(ctx).ExecContext(ctx, sql, args...)
+	if err != nil {
+		return nil, ClassifyError(err, cp.classify)
+	}
+
+	return res, nil
 }
 
 // Query executes a SQL query that returns rows, such as a SELECT statement, using the provided context and arguments.
 func (cp *connPool) Query(ctx context.Context, sql string, args ...any) (Rows, error) {
 	rows, err := cp.get(ctx).QueryContext(ctx, sql, args...)
 	if err != nil {
-		return nil, err
+		return nil, ClassifyError(err, cp.classify)
 	}
 
 	return &rowsResult{
-		rows: rows,
+		rows:     rows,
+		classify: cp.classify,
 	}, nil
 }
 
 // QueryRow executes a SQL query that is expected to return at most one row using the provided context and arguments.
 func (cp *connPool) QueryRow(ctx context.Context, sql string, args ...any) Row {
-	return cp.get(ctx).QueryRowContext(ctx, sql, args...)
+	return &rowResult{
+		row:      cp.get(ctx).QueryRowContext(ctx, sql, args...),
+		classify: cp.classify,
+	}
 }
 
 // Transact executes a function within a database transaction, handling commit or rollback based on an execution outcome.
 // Accepts a context and a handler function as parameters. Returns an error if the transaction fails or the handler returns an error.
+// The options set the isolation level and the read-only mode, the transaction is ReadCommitted by default.
+// If the context already holds a transaction, the handler is executed within a savepoint of it and the options are ignored.
+// The retryable errors are classified by the function set with WithRetryable.
 func (cp *connPool) Transact(
 	ctx context.Context,
 	handler func(ctx context.Context) error,
+	opts ...TxOption,
+) error {
+	if state, ok := ctx.Value(txKey).(*txState); ok {
+		return ClassifyError(cp.savepoint(ctx, state, handler), cp.classify)
+	}
+
+	options := NewTxOptions(opts...)
+	if options.Retry == nil {
+		return ClassifyError(cp.transact(ctx, handler, options), cp.classify)
+	}
+
+	return options.Retry.Do(ctx, cp.retryable, func() error {
+		return ClassifyError(cp.transact(ctx, handler, options), cp.classify)
+	})
+}
+
+// transact executes a function within a new database transaction started with the given options.
+func (cp *connPool) transact(
+	ctx context.Context,
+	handler func(ctx context.Context) error,
+	options *TxOptions,
 ) (err error) {
-	tx, err := cp.stdDb.BeginTx(ctx, txOptions)
+	var beginner txBeginner = cp.stdDb
+	if options.ReadOnly && cp.options.Dialect == driver.DialectSqlite {
+		conn, err := queryOnlyConn(ctx, cp.stdDb)
+		if err != nil {
+			return err
+		}
+
+		defer releaseQueryOnlyConn(ctx, conn)
+		beginner = conn
+	}
+
+	tx, err := beginner.BeginTx(ctx, &sql.TxOptions{Isolation: options.Isolation, ReadOnly: options.ReadOnly})
 	if err != nil {
 		return err
 	}
 
+	txCtx, hooks := WithTxHooks(ctx)
 	defer func() {
 		if err != nil {
 			rollBackErr := tx.Rollback()
@@ -103,9 +191,42 @@ func (cp *connPool) Transact(
 				err = commitErr
 			}
 		}
+
+		hooks.End(ctx, err)
 	}()
 
-	err = handler(context.WithValue(ctx, txKey, tx))
+	err = handler(context.WithValue(txCtx, txKey, &txState{tx: tx}))
+	return
+}
+
+// savepoint executes a function within a savepoint of the transaction.
+// Rolls back to the savepoint if the function returns an error, otherwise releases it.
+func (cp *connPool) savepoint(
+	ctx context.Context,
+	state *txState,
+	handler func(ctx context.Context) error,
+) (err error) {
+	nested := &txState{tx: state.tx, depth: state.depth + 1}
+	create, rollback, release := savepointStmts("op_savepoint_"+strconv.Itoa(nested.depth), cp.options.Dialect)
+	if _, err = state.tx.ExecContext(ctx, create); err != nil {
+		return err
+	}
+
+	txCtx, hooks := WithTxHooks(ctx)
+	defer func() {
+		if err != nil {
+			_, rollBackErr := state.tx.ExecContext(ctx, rollback)
+			if rollBackErr != nil {
+				err = errors.Join(err, rollBackErr)
+			}
+		} else if release != "" {
+			_, err = state.tx.ExecContext(ctx, release)
+		}
+
+		hooks.End(ctx, err)
+	}()
+
+	err = handler(context.WithValue(txCtx, txKey, nested))
 	return
 }
 
@@ -126,14 +247,48 @@ func (cp *connPool) SqlOptions() *driver.SqlOptions {
 
 // get retrieves the database from the context if a transaction exists, otherwise returns the default standard database.
 func (cp *connPool) get(ctx context.Context) stdDb {
-	tx := ctx.Value(txKey)
-	if tx != nil {
-		return tx.(stdDb)
+	if state, ok := ctx.Value(txKey).(*txState); ok {
+		return state.tx
 	}
 
 	return cp.stdDb
 }
 
+// savepointStmts returns the statements creating, rolling back and releasing the savepoint with the given name.
+// SQL Server has no statement releasing a savepoint, so the release statement is empty for it.
+func savepointStmts(name string, dialect driver.Dialect) (string, string, string) {
+	if dialect == driver.DialectMssql {
+		return "SAVE TRANSACTION " + name, "ROLLBACK TRANSACTION " + name, ""
+	}
+
+	return "SAVEPOINT " + name, "ROLLBACK TO SAVEPOINT " + name, "RELEASE SAVEPOINT " + name
+}
+
+// queryOnlyConn takes a connection from the pool and switches it to the query-only mode,
+// which makes sqlite reject the data modifications. The sqlite drivers ignore the read-only option of the transaction.
+func queryOnlyConn(ctx context.Context, db *sql.DB) (*sql.Conn, error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = conn.ExecContext(ctx, "PRAGMA query_only = ON"); err != nil {
+		return nil, errors.Join(err, conn.Close())
+	}
+
+	return conn, nil
+}
+
+// releaseQueryOnlyConn switches the connection back from the query-only mode and returns it to the pool.
+// The connection is discarded if the mode can't be switched, so it isn't reused by the other queries.
+func releaseQueryOnlyConn(ctx context.Context, conn *sql.Conn) {
+	if _, err := conn.ExecContext(context.WithoutCancel(ctx), "PRAGMA query_only = OFF"); err != nil {
+		conn.Raw(func(any) error { return sqldriver.ErrBadConn }) // nolint: errcheck, gosec
+	}
+
+	conn.Close() // nolint: errcheck, gosec
+}
+
 // Rows return a sequence of indexed rows from the sql.Rows object, allowing iteration with a yield function.
 // Each row is accessed as an index and a Scanner interface.
 // The method also updates the error state of the rowsResult instance after iteration.
@@ -164,5 +319,11 @@ func (rr *rowsResult) Close() {
 
 // Err returns the error encountered during iteration over rows or nil if no error occurred.
 func (rr *rowsResult) Err() error {
-	return rr.err
+	return ClassifyError(rr.err, rr.classify)
+}
+
+// Scan copies the columns of the row into the values pointed at by dest.
+// Returns the classified error, such as ErrNoRows if the query selected no rows.
+func (rr *rowResult) Scan(dest ...any) error {
+	return ClassifyError(rr.row.Scan(dest...), rr.classify)
 }
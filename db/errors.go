@@ -0,0 +1,69 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+)
+
+var (
+	ErrUniqueViolation     = errors.New("unique violation")
+	ErrForeignKeyViolation = errors.New("foreign key violation")
+	ErrNotNullViolation    = errors.New("not null violation")
+	ErrCheckViolation      = errors.New("check violation")
+	ErrSerialization       = errors.New("serialization failure")
+	ErrDeadlock            = errors.New("deadlock")
+	ErrNoRows              = errors.New("no rows")
+)
+
+// ErrorClassifier returns the classified error of the driver error, or nil if the driver error is not recognized.
+type ErrorClassifier func(err error) *Error
+
+// Error is a driver error classified by the ConnPool, which is matched by errors.Is with the sentinel error of its Kind.
+// The original driver error stays available for errors.Is and errors.As.
+type Error struct {
+	// Kind is the sentinel error of the class, such as ErrUniqueViolation.
+	Kind error
+	// Constraint is the name of the violated constraint, if the driver provides it.
+	Constraint string
+	// Table is the name of the table, if the driver provides it.
+	Table string
+	// Column is the name of the column, if the driver provides it.
+	Column string
+	// Err is the original driver error.
+	Err error
+}
+
+// Error returns the message of the original driver error.
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the sentinel error of the class and the original driver error.
+func (e *Error) Unwrap() []error {
+	return []error{e.Kind, e.Err}
+}
+
+// ClassifyError converts the driver error into an Error using the classifier. The sql.ErrNoRows is classified as ErrNoRows.
+// Returns the error unchanged if it is nil, already classified or not recognized by the classifier.
+func ClassifyError(err error, classify ErrorClassifier) error {
+	if err == nil {
+		return nil
+	}
+
+	var classified *Error
+	if errors.As(err, &classified) {
+		return err
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return &Error{Kind: ErrNoRows, Err: err}
+	}
+
+	if classify != nil {
+		if classified = classify(err); classified != nil {
+			return classified
+		}
+	}
+
+	return err
+}
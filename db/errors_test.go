@@ -0,0 +1,43 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyError(t *testing.T) {
+	t.Parallel()
+	errDriver := errors.New("duplicate")
+	classify := func(err error) *Error {
+		if errors.Is(err, errDriver) {
+			return &Error{Kind: ErrUniqueViolation, Constraint: "users_email_key", Err: err}
+		}
+
+		return nil
+	}
+
+	require.NoError(t, ClassifyError(nil, classify))
+
+	err := ClassifyError(fmt.Errorf("insert: %w", errDriver), classify)
+	require.ErrorIs(t, err, ErrUniqueViolation)
+	require.ErrorIs(t, err, errDriver)
+	require.NotErrorIs(t, err, ErrCheckViolation)
+	require.EqualError(t, err, "insert: duplicate")
+
+	var classified *Error
+	require.ErrorAs(t, err, &classified)
+	require.Equal(t, "users_email_key", classified.Constraint)
+	require.Same(t, err, ClassifyError(err, classify))
+
+	err = ClassifyError(sql.ErrNoRows, nil)
+	require.ErrorIs(t, err, ErrNoRows)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	errOther := errors.New("other")
+	require.Same(t, errOther, ClassifyError(errOther, classify))
+	require.Same(t, errOther, ClassifyError(errOther, nil))
+}
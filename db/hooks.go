@@ -0,0 +1,101 @@
+package db
+
+import (
+	"context"
+	"sync"
+)
+
+// TxHooks holds the callbacks registered within a transaction or a savepoint by AfterCommit and AfterRollback.
+// The ConnPool implementations create the hooks with WithTxHooks and call End when the transaction ends.
+type TxHooks struct {
+	mu            sync.Mutex
+	parent        *TxHooks
+	afterCommit   []func(ctx context.Context)
+	afterRollback []func(ctx context.Context)
+}
+
+// txHooksType represents a custom string type used as the context key of the transaction hooks.
+type txHooksType string
+
+// txHooksKey is a predefined key of type txHooksType used to store and retrieve the transaction hooks within a context.
+var txHooksKey = txHooksType("tx_hooks")
+
+// WithTxHooks returns a copy of the context holding the hooks of a new transaction.
+// The hooks are nested in the hooks of the context if it already holds a transaction, which makes them the hooks of a savepoint.
+func WithTxHooks(ctx context.Context) (context.Context, *TxHooks) {
+	hooks := &TxHooks{}
+	hooks.parent, _ = ctx.Value(txHooksKey).(*TxHooks)
+	return context.WithValue(ctx, txHooksKey, hooks), hooks
+}
+
+// AfterCommit registers the callback called after the transaction of the context is committed.
+// Within a savepoint, the callback waits for the commit of the outermost transaction and is dropped if the savepoint is rolled back.
+// Outside a transaction, the callback is called immediately.
+func AfterCommit(ctx context.Context, callback func(ctx context.Context)) {
+	hooks, ok := ctx.Value(txHooksKey).(*TxHooks)
+	if !ok {
+		callback(ctx)
+		return
+	}
+
+	hooks.mu.Lock()
+	defer hooks.mu.Unlock()
+	hooks.afterCommit = append(hooks.afterCommit, callback)
+}
+
+// AfterRollback registers the callback called after the transaction of the context is rolled back or fails to commit.
+// Within a savepoint, the callback is called after the rollback to the savepoint or the rollback of any outer transaction.
+// Outside a transaction, the callback is never called.
+func AfterRollback(ctx context.Context, callback func(ctx context.Context)) {
+	hooks, ok := ctx.Value(txHooksKey).(*TxHooks)
+	if !ok {
+		return
+	}
+
+	hooks.mu.Lock()
+	defer hooks.mu.Unlock()
+	hooks.afterRollback = append(hooks.afterRollback, callback)
+}
+
+// End calls the callbacks of the hooks when the transaction or the savepoint ends.
+// A nil error means the commit or the release of the savepoint, otherwise the rollback.
+func (h *TxHooks) End(ctx context.Context, err error) {
+	if err != nil {
+		h.rollback(ctx)
+	} else {
+		h.commit(ctx)
+	}
+}
+
+// commit calls the AfterCommit callbacks in the registration order.
+// The hooks of a released savepoint are passed to the parent hooks instead, so they fire when the outer transaction ends.
+func (h *TxHooks) commit(ctx context.Context) {
+	h.mu.Lock()
+	afterCommit, afterRollback := h.afterCommit, h.afterRollback
+	h.afterCommit, h.afterRollback = nil, nil
+	h.mu.Unlock()
+
+	if h.parent != nil {
+		h.parent.mu.Lock()
+		defer h.parent.mu.Unlock()
+		h.parent.afterCommit = append(h.parent.afterCommit, afterCommit...)
+		h.parent.afterRollback = append(h.parent.afterRollback, afterRollback...)
+		return
+	}
+
+	for _, callback := range afterCommit {
+		callback(ctx)
+	}
+}
+
+// rollback calls the AfterRollback callbacks in the registration order and drops the AfterCommit callbacks.
+func (h *TxHooks) rollback(ctx context.Context) {
+	h.mu.Lock()
+	afterRollback := h.afterRollback
+	h.afterCommit, h.afterRollback = nil, nil
+	h.mu.Unlock()
+
+	for _, callback := range afterRollback {
+		callback(ctx)
+	}
+}
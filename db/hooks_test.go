@@ -0,0 +1,80 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxHooks(t *testing.T) {
+	t.Parallel()
+	errTx := errors.New("tx")
+	cases := []struct {
+		name     string
+		innerErr error
+		outerErr error
+		expected []string
+	}{
+		{
+			name:     "commit",
+			expected: []string{"outer_commit", "inner_commit"},
+		},
+		{
+			name:     "inner_rollback",
+			innerErr: errTx,
+			expected: []string{"inner_rollback", "outer_commit"},
+		},
+		{
+			name:     "outer_rollback",
+			outerErr: errTx,
+			expected: []string{"outer_rollback", "inner_rollback"},
+		},
+		{
+			name:     "both_rollback",
+			innerErr: errTx,
+			outerErr: errTx,
+			expected: []string{"inner_rollback", "outer_rollback"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			var calls []string
+			register := func(ctx context.Context, prefix string) {
+				AfterCommit(ctx, func(ctx context.Context) {
+					calls = append(calls, prefix+"_commit")
+				})
+				AfterRollback(ctx, func(ctx context.Context) {
+					calls = append(calls, prefix+"_rollback")
+				})
+			}
+
+			ctx := context.Background()
+			outerCtx, outer := WithTxHooks(ctx)
+			register(outerCtx, "outer")
+
+			innerCtx, inner := WithTxHooks(outerCtx)
+			register(innerCtx, "inner")
+			inner.End(outerCtx, c.innerErr)
+			outer.End(ctx, c.outerErr)
+
+			require.Equal(t, c.expected, calls)
+		})
+	}
+}
+
+func TestTxHooksNoTransaction(t *testing.T) {
+	t.Parallel()
+	var calls []string
+	AfterCommit(context.Background(), func(ctx context.Context) {
+		calls = append(calls, "commit")
+	})
+	AfterRollback(context.Background(), func(ctx context.Context) {
+		calls = append(calls, "rollback")
+	})
+
+	require.Equal(t, []string{"commit"}, calls)
+}
@@ -0,0 +1,26 @@
+package mssql
+
+import (
+	"strconv"
+
+	"github.com/xsqrty/op/driver"
+)
+
+// NewSqlOptions creates a new instance of SqlOptions with predefined configurations for SQL Server.
+// Identifiers are quoted with square brackets and arguments use the "@p1" placeholders.
+func NewSqlOptions() *driver.SqlOptions {
+	return driver.NewSqlOptions(
+		driver.WithDialect(driver.DialectMssql),
+		driver.WithSafeColumns(),
+		driver.WithColumnsDelim('.'),
+		driver.WithFieldsDelim(','),
+		driver.WithWrapColumn('[', ']'),
+		driver.WithWrapAlias('[', ']'),
+		driver.WithCastFormat(func(val string, typ string) string {
+			return "CAST(" + val + " AS " + typ + ")"
+		}),
+		driver.WithPlaceholderFormat(func(n int) string {
+			return "@p" + strconv.Itoa(n)
+		}),
+	)
+}
@@ -0,0 +1,202 @@
+package mssql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xsqrty/op"
+	"github.com/xsqrty/op/driver"
+	"github.com/xsqrty/op/internal/testutil"
+)
+
+func TestNewSqlOptions(t *testing.T) {
+	t.Parallel()
+	options := NewSqlOptions()
+	sql, args, err := driver.Sql(driver.Pure("?", 1), options)
+	cast := options.CastFormat(sql, "INT")
+
+	require.NoError(t, err)
+	require.Equal(t, "CAST(@p1 AS INT)", cast)
+	require.Equal(t, []any{1}, args)
+	require.Equal(t, driver.DialectMssql, options.Dialect)
+}
+
+func TestGoldenSql(t *testing.T) {
+	t.Parallel()
+	testutil.RunCases(t, NewSqlOptions(), []testutil.TestCase{
+		{
+			Name:         "select_top",
+			Builder:      op.Select("id", "users.name").From("users").Where(op.Eq("active", true)).Limit(10),
+			ExpectedSql:  "SELECT TOP 10 [id],[users].[name] FROM [users] WHERE [active] = ?",
+			ExpectedArgs: []any{true},
+		},
+		{
+			Name: "select_offset_fetch",
+			Builder: op.Select().From("users").
+				Where(op.Gt("age", 18)).
+				OrderBy(op.Desc("created_at")).
+				Limit(20).
+				Offset(40),
+			ExpectedSql:  "SELECT * FROM [users] WHERE [age] > ? ORDER BY [created_at] DESC OFFSET ? ROWS FETCH NEXT ? ROWS ONLY",
+			ExpectedArgs: []any{18, uint64(40), uint64(20)},
+		},
+		{
+			Name:         "select_offset_unordered",
+			Builder:      op.Select().From("users").Offset(5),
+			ExpectedSql:  "SELECT * FROM [users] ORDER BY (SELECT NULL) OFFSET ? ROWS",
+			ExpectedArgs: []any{uint64(5)},
+		},
+		{
+			Name: "select_cte_recursive",
+			Builder: op.Select().
+				CteRecursive("tree", op.UnionAll(
+					op.Select("id", "parent_id").From("categories").Where(op.Eq("parent_id", nil)),
+					op.Select("categories.id", "categories.parent_id").From("categories").Join("tree", op.Eq("categories.parent_id", op.Column("tree.id"))),
+				), "id", "parent_id").
+				From("tree"),
+			ExpectedSql:  "WITH [tree] ([id],[parent_id]) AS (SELECT [id],[parent_id] FROM [categories] WHERE [parent_id] IS NULL UNION ALL SELECT [categories].[id],[categories].[parent_id] FROM [categories] JOIN [tree] ON [categories].[parent_id] = [tree].[id]) SELECT * FROM [tree]",
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "insert_output",
+			Builder:      op.Insert("users", op.Inserting{"name": "Alex"}).Returning("id", "users.name"),
+			ExpectedSql:  "INSERT INTO [users] ([name]) OUTPUT INSERTED.[id],INSERTED.[name] VALUES (?)",
+			ExpectedArgs: []any{"Alex"},
+		},
+		{
+			Name: "update_output",
+			Builder: op.Update("users", op.Updates{"name": "Bob"}).
+				Where(op.Eq("id", 1)).
+				Returning("id", op.As("lower_name", op.Lower(op.Column("INSERTED.name")))),
+			ExpectedSql:  "UPDATE [users] SET [name]=? OUTPUT INSERTED.[id],(LOWER([INSERTED].[name])) AS [lower_name] WHERE [id] = ?",
+			ExpectedArgs: []any{"Bob", 1},
+		},
+		{
+			Name:         "delete_output",
+			Builder:      op.Delete("users").Where(op.Eq("id", 1)).Returning("id"),
+			ExpectedSql:  "DELETE FROM [users] OUTPUT DELETED.[id] WHERE [id] = ?",
+			ExpectedArgs: []any{1},
+		},
+		{
+			Name: "delete_using",
+			Builder: op.Delete("users").
+				Using("companies").
+				Where(op.And{op.Eq("users.company_id", op.Column("companies.id")), op.Eq("companies.name", "acme")}),
+			ExpectedSql:  "DELETE FROM [users] FROM [companies] WHERE ([users].[company_id] = [companies].[id] AND [companies].[name] = ?)",
+			ExpectedArgs: []any{"acme"},
+		},
+		{
+			Name: "merge",
+			Builder: op.Merge("users").
+				Using("staging", op.Eq("users.id", op.Column("staging.id"))).
+				WhenMatched(nil, op.MergeUpdate(op.Updates{"name": op.Column("staging.name")})).
+				WhenNotMatched(nil, op.MergeInsert(op.Inserting{"name": op.Column("staging.name")})),
+			ExpectedSql:  "MERGE INTO [users] USING [staging] ON [users].[id] = [staging].[id] WHEN MATCHED THEN UPDATE SET [name]=[staging].[name] WHEN NOT MATCHED THEN INSERT ([name]) VALUES ([staging].[name]);",
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name: "compound_limit_one",
+			Builder: func() driver.Sqler {
+				cb := op.Union(op.Select("id").From("users"), op.Select("id").From("admins")).OrderBy(op.Asc("id"))
+				cb.LimitReturningOne()
+				return cb
+			}(),
+			ExpectedSql:  "SELECT TOP 1 * FROM (SELECT [id] FROM [users] UNION SELECT [id] FROM [admins]) AS [users] ORDER BY [id] ASC",
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "extract_text",
+			Builder:      op.Select().From("users").Where(op.Eq(op.ExtractText("data", "profile", 0), "alex")),
+			ExpectedSql:  `SELECT * FROM [users] WHERE JSON_VALUE([data],?) = ?`,
+			ExpectedArgs: []any{`$."profile"[0]`, "alex"},
+		},
+		{
+			Name: "string_agg",
+			Builder: op.Select("company_id", op.As("names", op.StringAgg("name", ", ").OrderBy(op.Asc("name")))).
+				From("users").
+				GroupBy("company_id"),
+			ExpectedSql:  "SELECT [company_id],(STRING_AGG([name],?) WITHIN GROUP (ORDER BY [name] ASC)) AS [names] FROM [users] GROUP BY [company_id]",
+			ExpectedArgs: []any{", "},
+		},
+		{
+			Name:         "error_json_agg",
+			Builder:      op.Select(op.As("ids", op.JsonAgg("id"))).From("users"),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `aggregate JSON_AGG: unsupported in dialect "mssql"`,
+		},
+		{
+			Name: "create_table",
+			Builder: op.CreateTable("users").Columns(
+				op.ColumnDef("id", op.TypeBigSerial()).PrimaryKey(),
+				op.ColumnDef("name", op.TypeText()).NotNull().Default("it's"),
+				op.ColumnDef("active", op.TypeBoolean()).NotNull().Default(true),
+			),
+			ExpectedSql:  "CREATE TABLE [users] ([id] BIGINT IDENTITY(1,1) PRIMARY KEY,[name] NVARCHAR(MAX) NOT NULL DEFAULT 'it''s',[active] BIT NOT NULL DEFAULT 1)",
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name: "alter_table",
+			Builder: op.AlterTable("users").
+				AddColumn(op.ColumnDef("deleted", op.TypeBoolean()).Default(false)).
+				DropColumn("legacy").
+				AlterColumnType("age", op.TypeSmallInt()).
+				DropConstraint("users_legacy_key"),
+			ExpectedSql:  "ALTER TABLE [users] ADD [deleted] BIT DEFAULT 0; ALTER TABLE [users] DROP COLUMN [legacy]; ALTER TABLE [users] ALTER COLUMN [age] SMALLINT; ALTER TABLE [users] DROP CONSTRAINT [users_legacy_key]",
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "create_index",
+			Builder:      op.CreateIndex("users_email_idx", "users", "email").Unique().Concurrently().Where(op.Eq("active", true)),
+			ExpectedSql:  "CREATE UNIQUE INDEX [users_email_idx] ON [users] ([email]) WHERE [active] = 1",
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "drop_index",
+			Builder:      op.DropIndex("users_email_idx").IfExists().On("users"),
+			ExpectedSql:  "DROP INDEX IF EXISTS [users_email_idx] ON [users]",
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "create_table_if_not_exists",
+			Builder:      op.CreateTable("users").IfNotExists().Columns(op.ColumnDef("id", op.TypeInteger())),
+			ExpectedSql:  "IF OBJECT_ID('users','U') IS NULL CREATE TABLE [users] ([id] INTEGER)",
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "error_rename_column",
+			Builder:      op.AlterTable("users").RenameColumn("name", "full_name"),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `alter table rename column: unsupported in dialect "mssql"`,
+		},
+		{
+			Name:         "error_set_default",
+			Builder:      op.AlterTable("users").SetDefault("active", true),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `alter table set default: unsupported in dialect "mssql"`,
+		},
+		{
+			Name:         "error_create_index_if_not_exists",
+			Builder:      op.CreateIndex("users_email_idx", "users", "email").IfNotExists(),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `create index if not exists: unsupported in dialect "mssql"`,
+		},
+		{
+			Name:         "error_drop_index_table",
+			Builder:      op.DropIndex("users_email_idx"),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `drop index: table of the index "users_email_idx" is required for dialect "mssql"`,
+		},
+		{
+			Name:         "error_row_locking",
+			Builder:      op.Select().From("users").ForUpdate(),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `row locking: unsupported in dialect "mssql"`,
+		},
+	})
+}
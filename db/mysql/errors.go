@@ -0,0 +1,97 @@
+package mysql
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/xsqrty/op/db"
+)
+
+const (
+	// errDupEntry is the error number of the unique key violations.
+	errDupEntry = 1062
+	// errBadNull is the error number of the NULL values of the NOT NULL columns.
+	errBadNull = 1048
+	// errNoDefaultForField is the error number of the missing values of the NOT NULL columns without a default.
+	errNoDefaultForField = 1364
+	// errRowIsReferenced is the error number of the deleted or updated parent rows of a foreign key.
+	errRowIsReferenced = 1451
+	// errNoReferencedRow is the error number of the child rows referencing a missing parent row.
+	errNoReferencedRow = 1452
+	// errCheckConstraintViolated is the error number of the check constraint violations.
+	errCheckConstraintViolated = 3819
+	// errLockWaitTimeout is the error number of the statements that exceeded the lock wait timeout.
+	errLockWaitTimeout = 1205
+	// errLockDeadlock is the error number of the transactions rolled back by the deadlock detection.
+	errLockDeadlock = 1213
+)
+
+var (
+	// dupEntryRe matches the key of the message such as "Duplicate entry 'a' for key 'users.email'".
+	dupEntryRe = regexp.MustCompile(`for key '([^']*)'$`)
+	// quotedRe matches the name of the message such as "Column 'name' cannot be null".
+	quotedRe = regexp.MustCompile(`'([^']*)'`)
+	// foreignKeyRe matches the table, the constraint and the column of the foreign key violation message,
+	// such as "(`db`.`orders`, CONSTRAINT `fk_user` FOREIGN KEY (`user_id`) REFERENCES ...".
+	foreignKeyRe = regexp.MustCompile("`([^`]*)`, CONSTRAINT `([^`]*)` FOREIGN KEY \\(`([^`]*)`\\)")
+)
+
+// IsRetryable reports whether the error is a deadlock or a lock wait timeout, after which the transaction can be retried.
+func IsRetryable(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if !errors.As(err, &mysqlErr) {
+		return false
+	}
+
+	return mysqlErr.Number == errLockDeadlock || mysqlErr.Number == errLockWaitTimeout
+}
+
+// classifyError classifies the mysql error by its number, taking the constraint, table and column names from the message.
+func classifyError(err error) *db.Error {
+	var mysqlErr *mysql.MySQLError
+	if !errors.As(err, &mysqlErr) {
+		return nil
+	}
+
+	switch mysqlErr.Number {
+	case errDupEntry:
+		classified := &db.Error{Kind: db.ErrUniqueViolation, Err: err}
+		if match := dupEntryRe.FindStringSubmatch(mysqlErr.Message); match != nil {
+			// mysql 8 qualifies the key with the table name
+			if table, key, ok := strings.Cut(match[1], "."); ok {
+				classified.Table, classified.Constraint = table, key
+			} else {
+				classified.Constraint = match[1]
+			}
+		}
+
+		return classified
+	case errBadNull, errNoDefaultForField:
+		classified := &db.Error{Kind: db.ErrNotNullViolation, Err: err}
+		if match := quotedRe.FindStringSubmatch(mysqlErr.Message); match != nil {
+			classified.Column = match[1]
+		}
+
+		return classified
+	case errRowIsReferenced, errNoReferencedRow:
+		classified := &db.Error{Kind: db.ErrForeignKeyViolation, Err: err}
+		if match := foreignKeyRe.FindStringSubmatch(mysqlErr.Message); match != nil {
+			classified.Table, classified.Constraint, classified.Column = match[1], match[2], match[3]
+		}
+
+		return classified
+	case errCheckConstraintViolated:
+		classified := &db.Error{Kind: db.ErrCheckViolation, Err: err}
+		if match := quotedRe.FindStringSubmatch(mysqlErr.Message); match != nil {
+			classified.Constraint = match[1]
+		}
+
+		return classified
+	case errLockDeadlock:
+		return &db.Error{Kind: db.ErrDeadlock, Err: err}
+	}
+
+	return nil
+}
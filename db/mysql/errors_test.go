@@ -0,0 +1,83 @@
+package mysql
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/require"
+	"github.com/xsqrty/op/db"
+)
+
+func TestIsRetryable(t *testing.T) {
+	t.Parallel()
+	require.True(t, IsRetryable(&mysql.MySQLError{Number: 1213}))
+	require.True(t, IsRetryable(fmt.Errorf("commit: %w", &mysql.MySQLError{Number: 1205})))
+	require.False(t, IsRetryable(&mysql.MySQLError{Number: 1062}))
+	require.False(t, IsRetryable(errors.New("deadlock")))
+}
+
+func TestClassifyError(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name     string
+		err      *mysql.MySQLError
+		expected *db.Error
+	}{
+		{
+			name:     "unique",
+			err:      &mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'alex@example.com' for key 'users.email'"},
+			expected: &db.Error{Kind: db.ErrUniqueViolation, Constraint: "email", Table: "users"},
+		},
+		{
+			name:     "unique_unqualified",
+			err:      &mysql.MySQLError{Number: 1062, Message: "Duplicate entry '1' for key 'PRIMARY'"},
+			expected: &db.Error{Kind: db.ErrUniqueViolation, Constraint: "PRIMARY"},
+		},
+		{
+			name: "foreign_key",
+			err: &mysql.MySQLError{
+				Number:  1452,
+				Message: "Cannot add or update a child row: a foreign key constraint fails (`shop`.`orders`, CONSTRAINT `fk_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`))",
+			},
+			expected: &db.Error{Kind: db.ErrForeignKeyViolation, Constraint: "fk_user", Table: "orders", Column: "user_id"},
+		},
+		{
+			name:     "not_null",
+			err:      &mysql.MySQLError{Number: 1048, Message: "Column 'name' cannot be null"},
+			expected: &db.Error{Kind: db.ErrNotNullViolation, Column: "name"},
+		},
+		{
+			name:     "no_default",
+			err:      &mysql.MySQLError{Number: 1364, Message: "Field 'name' doesn't have a default value"},
+			expected: &db.Error{Kind: db.ErrNotNullViolation, Column: "name"},
+		},
+		{
+			name:     "check",
+			err:      &mysql.MySQLError{Number: 3819, Message: "Check constraint 'users_chk_1' is violated."},
+			expected: &db.Error{Kind: db.ErrCheckViolation, Constraint: "users_chk_1"},
+		},
+		{
+			name:     "deadlock",
+			err:      &mysql.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock"},
+			expected: &db.Error{Kind: db.ErrDeadlock},
+		},
+		{
+			name:     "unknown",
+			err:      &mysql.MySQLError{Number: 1146, Message: "Table 'shop.items' doesn't exist"},
+			expected: nil,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			if c.expected != nil {
+				c.expected.Err = c.err
+			}
+
+			require.Equal(t, c.expected, classifyError(c.err))
+		})
+	}
+}
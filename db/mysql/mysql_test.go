@@ -0,0 +1,122 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	sqle "github.com/dolthub/go-mysql-server"
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/server"
+	gmssql "github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/require"
+	"github.com/xsqrty/op"
+	"github.com/xsqrty/op/db"
+	"github.com/xsqrty/op/driver"
+	"github.com/xsqrty/op/orm"
+)
+
+type mockUser struct {
+	ID    int    `op:"id,primary"`
+	Name  string `op:"name"`
+	Email string `op:"email"`
+}
+
+func TestOpen(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn := startServer(t)
+
+	_, err := conn.Exec(ctx, "CREATE TABLE users (id INT AUTO_INCREMENT PRIMARY KEY, name TEXT NOT NULL, email VARCHAR(255) NOT NULL UNIQUE)")
+	require.NoError(t, err)
+
+	alex := &mockUser{Name: "Alex", Email: "alex@example.com"}
+	require.NoError(t, orm.Put("users", alex).With(ctx, conn))
+	require.Equal(t, 1, alex.ID)
+
+	bob := &mockUser{Name: "Bob", Email: "bob@example.com"}
+	require.NoError(t, orm.Put("users", bob).With(ctx, conn))
+	require.Equal(t, &mockUser{ID: 2, Name: "Bob", Email: "bob@example.com"}, bob)
+
+	alex.Name = "Alexander"
+	require.NoError(t, orm.Put("users", alex).With(ctx, conn))
+	require.Equal(t, 1, alex.ID)
+
+	users, err := orm.Query[mockUser](op.Select().From("users").OrderBy(op.Asc("id"))).GetMany(ctx, conn)
+	require.NoError(t, err)
+	require.Equal(t, []*mockUser{
+		{ID: 1, Name: "Alexander", Email: "alex@example.com"},
+		{ID: 2, Name: "Bob", Email: "bob@example.com"},
+	}, users)
+
+	_, err = orm.Exec(op.Insert("users", op.Inserting{"id": 3, "name": "Alex", "email": "alex@example.com"}).
+		OnConflict("email", op.DoNothing())).
+		With(ctx, conn)
+	require.NoError(t, err)
+
+	_, err = orm.Exec(op.Insert("users", op.Inserting{"name": "Bob", "email": "bob@example.com"}).
+		OnConflict("email", op.DoUpdate(op.Updates{"name": op.Concat(op.Excluded("name"), driver.Value("!"))}))).
+		With(ctx, conn)
+	require.NoError(t, err)
+
+	count, err := orm.Count(op.Select().From("users")).With(ctx, conn)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+
+	duplicate := &mockUser{Name: "Mallory", Email: "alex@example.com"}
+	require.ErrorIs(t, orm.Put("users", duplicate).With(ctx, conn), db.ErrUniqueViolation)
+	require.Equal(t, 0, duplicate.ID)
+
+	collision := &mockUser{ID: 99, Name: "Eve", Email: "alex@example.com"}
+	require.ErrorIs(t, orm.Put("users", collision).With(ctx, conn), db.ErrUniqueViolation)
+
+	unchanged := &mockUser{ID: 2, Name: "Bob!", Email: "bob@example.com"}
+	require.NoError(t, orm.Put("users", unchanged).With(ctx, conn))
+	require.Equal(t, &mockUser{ID: 2, Name: "Bob!", Email: "bob@example.com"}, unchanged)
+
+	carol := &mockUser{ID: 10, Name: "Carol", Email: "carol@example.com"}
+	require.NoError(t, orm.Put("users", carol).With(ctx, conn))
+	require.Equal(t, &mockUser{ID: 10, Name: "Carol", Email: "carol@example.com"}, carol)
+
+	users, err = orm.Query[mockUser](op.Select().From("users").OrderBy(op.Asc("id"))).GetMany(ctx, conn)
+	require.NoError(t, err)
+	require.Equal(t, []*mockUser{
+		{ID: 1, Name: "Alexander", Email: "alex@example.com"},
+		{ID: 2, Name: "Bob!", Email: "bob@example.com"},
+		{ID: 10, Name: "Carol", Email: "carol@example.com"},
+	}, users)
+}
+
+func startServer(t *testing.T) db.ConnPool {
+	t.Helper()
+	database := memory.NewDatabase("test")
+	database.BaseDatabase.EnablePrimaryKeyIndexes()
+
+	provider := memory.NewDBProvider(database)
+	srv, err := server.NewServer(
+		server.Config{Protocol: "tcp", Address: "127.0.0.1:0"},
+		sqle.NewDefault(provider),
+		gmssql.NewContext,
+		memory.NewSessionBuilder(provider),
+		nil,
+	)
+	require.NoError(t, err)
+
+	go srv.Start() // nolint: errcheck
+	t.Cleanup(func() {
+		srv.Close() // nolint: errcheck, gosec
+	})
+
+	conn, err := Open(
+		fmt.Sprintf("root@tcp(%s)/test", srv.Listener.Addr()),
+		WithMaxOpenConns(1),
+		WithConnMaxLifetime(time.Minute),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		conn.Close() // nolint: errcheck, gosec
+	})
+
+	return conn
+}
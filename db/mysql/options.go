@@ -0,0 +1,21 @@
+package mysql
+
+import (
+	"github.com/xsqrty/op/driver"
+)
+
+// NewSqlOptions creates a new instance of SqlOptions with predefined configurations for mysql.
+// Identifiers are quoted with backticks and arguments use the "?" placeholders.
+func NewSqlOptions() *driver.SqlOptions {
+	return driver.NewSqlOptions(
+		driver.WithDialect(driver.DialectMysql),
+		driver.WithSafeColumns(),
+		driver.WithColumnsDelim('.'),
+		driver.WithFieldsDelim(','),
+		driver.WithWrapColumn('`', '`'),
+		driver.WithWrapAlias('`', '`'),
+		driver.WithCastFormat(func(val string, typ string) string {
+			return "CAST(" + val + " AS " + typ + ")"
+		}),
+	)
+}
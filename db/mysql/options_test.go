@@ -0,0 +1,20 @@
+package mysql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xsqrty/op/driver"
+)
+
+func TestNewSqlOptions(t *testing.T) {
+	t.Parallel()
+	options := NewSqlOptions()
+	sql, args, err := driver.Sql(driver.Pure("?", 1), options)
+	cast := options.CastFormat(sql, "SIGNED")
+
+	require.NoError(t, err)
+	require.Equal(t, "CAST(? AS SIGNED)", cast)
+	require.Equal(t, []any{1}, args)
+	require.Equal(t, driver.DialectMysql, options.Dialect)
+}
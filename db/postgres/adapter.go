@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"iter"
@@ -42,6 +43,11 @@ type pgxRows struct {
 	err  error
 }
 
+// pgxRow wraps pgx.Row to implement the db.Row interface, classifying the errors of Scan.
+type pgxRow struct {
+	row pgx.Row
+}
+
 // pgxExecResult wraps pgconn.CommandTag to implement the db.ExecResult interface, providing query execution results.
 type pgxExecResult struct {
 	commonTags *pgconn.CommandTag
@@ -50,9 +56,13 @@ type pgxExecResult struct {
 // pgxTxType represents a custom type for identifying or categorizing PostgreSQL transaction contexts as a string value.
 type pgxTxType string
 
-// pgxTxOptions defines transaction options with an isolation level set to ReadCommitted for PostgreSQL operations.
-var pgxTxOptions = pgx.TxOptions{
-	IsoLevel: pgx.ReadCommitted,
+// pgxIsoLevels maps the isolation levels of the standard library to the pgx isolation levels.
+var pgxIsoLevels = map[sql.IsolationLevel]pgx.TxIsoLevel{
+	sql.LevelDefault:         "",
+	sql.LevelReadUncommitted: pgx.ReadUncommitted,
+	sql.LevelReadCommitted:   pgx.ReadCommitted,
+	sql.LevelRepeatableRead:  pgx.RepeatableRead,
+	sql.LevelSerializable:    pgx.Serializable,
 }
 
 // pgxTxKey is a context key used to store and retrieve a transaction from a context object for pgx operations.
@@ -65,7 +75,7 @@ var _ db.ConnPool = (*pgxAdapter)(nil)
 func (pa *pgxAdapter) Exec(ctx context.Context, sql string, args ...any) (db.ExecResult, error) {
 	tags, err := pa.get(ctx).Exec(ctx, sql, args...)
 	if err != nil {
-		return nil, err
+		return nil, db.ClassifyError(err, classifyError)
 	}
 
 	return &pgxExecResult{commonTags: &tags}, nil
@@ -75,7 +85,7 @@ func (pa *pgxAdapter) Exec(ctx context.Context, sql string, args ...any) (db.Exe
 func (pa *pgxAdapter) Query(ctx context.Context, sql string, args ...any) (db.Rows, error) {
 	rows, err := pa.get(ctx).Query(ctx, sql, args...)
 	if err != nil {
-		return nil, err
+		return nil, db.ClassifyError(err, classifyError)
 	}
 
 	return &pgxRows{
@@ -85,21 +95,74 @@ func (pa *pgxAdapter) Query(ctx context.Context, sql string, args ...any) (db.Ro
 
 // QueryRow executes a database query that expects a single row result and returns it as a db.Row for processing.
 func (pa *pgxAdapter) QueryRow(ctx context.Context, sql string, args ...any) db.Row {
-	return pa.get(ctx).QueryRow(ctx, sql, args...)
+	return &pgxRow{row: pa.get(ctx).QueryRow(ctx, sql, args...)}
 }
 
 // Transact executes a function within a database transaction.
 // Commits the transaction if the function succeeds, rolls back on error.
-// Ctx provides context; handler is the function to execute.
+// Ctx provides context; handler is the function to execute; opts set the isolation level, read-only and deferrable modes
+// and the retry policy, which re-runs the transaction failed with an error reported by IsRetryable.
+// If the context already holds a transaction, the handler is executed within a savepoint of it and the options are ignored.
 func (pa *pgxAdapter) Transact(
 	ctx context.Context,
 	handler func(ctx context.Context) error,
+	opts ...db.TxOption,
+) error {
+	// the pgx nested transaction rolls back to the savepoint on Rollback and releases it on Commit
+	if tx, ok := ctx.Value(pgxTxKey).(pgx.Tx); ok {
+		return db.ClassifyError(pa.transact(ctx, handler, func() (pgx.Tx, error) {
+			return tx.Begin(ctx)
+		}), classifyError)
+	}
+
+	options := db.NewTxOptions(opts...)
+	txOptions, err := pgxTxOptions(options)
+	if err != nil {
+		return err
+	}
+
+	begin := func() (pgx.Tx, error) {
+		return pa.pool.BeginTx(ctx, txOptions)
+	}
+
+	if options.Retry == nil {
+		return db.ClassifyError(pa.transact(ctx, handler, begin), classifyError)
+	}
+
+	return options.Retry.Do(ctx, IsRetryable, func() error {
+		return db.ClassifyError(pa.transact(ctx, handler, begin), classifyError)
+	})
+}
+
+// Close gracefully closes the adapter's connection pool and releases all associated resources.
+func (pa *pgxAdapter) Close() error {
+	pa.pool.Close()
+	return nil
+}
+
+// Sql generates an SQL query string and arguments using the provided Sqler and adapter-specific options.
+func (pa *pgxAdapter) Sql(b driver.Sqler) (string, []any, error) {
+	return driver.Sql(b, pa.options)
+}
+
+// SqlOptions returns the SQL options configuration associated with the pgxAdapter instance.
+func (pa *pgxAdapter) SqlOptions() *driver.SqlOptions {
+	return pa.options
+}
+
+// transact executes a function within the transaction started by the begin function,
+// committing it if the function succeeds and rolling it back on error.
+func (pa *pgxAdapter) transact(
+	ctx context.Context,
+	handler func(ctx context.Context) error,
+	begin func() (pgx.Tx, error),
 ) (err error) {
-	tx, err := pa.pool.BeginTx(ctx, pgxTxOptions)
+	tx, err := begin()
 	if err != nil {
 		return err
 	}
 
+	txCtx, hooks := db.WithTxHooks(ctx)
 	defer func() {
 		if err != nil {
 			rollBackErr := tx.Rollback(ctx)
@@ -112,26 +175,32 @@ func (pa *pgxAdapter) Transact(
 				err = commitErr
 			}
 		}
+
+		hooks.End(ctx, err)
 	}()
 
-	err = handler(context.WithValue(ctx, pgxTxKey, tx))
+	err = handler(context.WithValue(txCtx, pgxTxKey, tx))
 	return
 }
 
-// Close gracefully closes the adapter's connection pool and releases all associated resources.
-func (pa *pgxAdapter) Close() error {
-	pa.pool.Close()
-	return nil
-}
+// pgxTxOptions converts the transaction options to the pgx transaction options.
+// Returns an ErrPgxUnsupported error if postgres has no equivalent of the isolation level.
+func pgxTxOptions(options *db.TxOptions) (pgx.TxOptions, error) {
+	isoLevel, ok := pgxIsoLevels[options.Isolation]
+	if !ok {
+		return pgx.TxOptions{}, fmt.Errorf("isolation level %s: %w", options.Isolation, ErrPgxUnsupported)
+	}
 
-// Sql generates an SQL query string and arguments using the provided Sqler and adapter-specific options.
-func (pa *pgxAdapter) Sql(b driver.Sqler) (string, []any, error) {
-	return driver.Sql(b, pa.options)
-}
+	txOptions := pgx.TxOptions{IsoLevel: isoLevel}
+	if options.ReadOnly {
+		txOptions.AccessMode = pgx.ReadOnly
+	}
 
-// SqlOptions returns the SQL options configuration associated with the pgxAdapter instance.
-func (pa *pgxAdapter) SqlOptions() *driver.SqlOptions {
-	return pa.options
+	if options.Deferrable {
+		txOptions.DeferrableMode = pgx.Deferrable
+	}
+
+	return txOptions, nil
 }
 
 // get retrieves the current execution context, returning a transaction if present or falling back to the connection pool.
@@ -178,7 +247,12 @@ func (pr *pgxRows) Close() {
 
 // Err returns the error encountered, if any, during iteration over the rows or result processing.
 func (pr *pgxRows) Err() error {
-	return pr.err
+	return db.ClassifyError(pr.err, classifyError)
+}
+
+// Scan reads the values of the row into dest. Returns the classified error, such as db.ErrNoRows if the query selected no rows.
+func (pr *pgxRow) Scan(dest ...any) error {
+	return db.ClassifyError(pr.row.Scan(dest...), classifyError)
 }
 
 // RowsAffected returns the number of rows affected by the execution of the query.
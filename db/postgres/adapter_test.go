@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+	"github.com/xsqrty/op/db"
+)
+
+func TestPgxTxOptions(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name     string
+		opts     []db.TxOption
+		expected pgx.TxOptions
+	}{
+		{
+			name:     "default",
+			expected: pgx.TxOptions{IsoLevel: pgx.ReadCommitted},
+		},
+		{
+			name:     "server_default",
+			opts:     []db.TxOption{db.WithIsolation(sql.LevelDefault)},
+			expected: pgx.TxOptions{},
+		},
+		{
+			name:     "repeatable_read",
+			opts:     []db.TxOption{db.WithIsolation(sql.LevelRepeatableRead)},
+			expected: pgx.TxOptions{IsoLevel: pgx.RepeatableRead},
+		},
+		{
+			name: "serializable_read_only_deferrable",
+			opts: []db.TxOption{db.WithIsolation(sql.LevelSerializable), db.WithReadOnly(), db.WithDeferrable()},
+			expected: pgx.TxOptions{
+				IsoLevel:       pgx.Serializable,
+				AccessMode:     pgx.ReadOnly,
+				DeferrableMode: pgx.Deferrable,
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			options, err := pgxTxOptions(db.NewTxOptions(c.opts...))
+			require.NoError(t, err)
+			require.Equal(t, c.expected, options)
+		})
+	}
+}
+
+func TestPgxTxOptionsError(t *testing.T) {
+	t.Parallel()
+	_, err := pgxTxOptions(db.NewTxOptions(db.WithIsolation(sql.LevelSnapshot)))
+	require.ErrorIs(t, err, ErrPgxUnsupported)
+	require.EqualError(t, err, "isolation level Snapshot: unsupported")
+}
@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/xsqrty/op/db"
+)
+
+const (
+	// sqlStateSerializationFailure is the SQLSTATE of the transactions that can't be serialized.
+	sqlStateSerializationFailure = "40001"
+	// sqlStateDeadlockDetected is the SQLSTATE of the transactions aborted by the deadlock detection.
+	sqlStateDeadlockDetected = "40P01"
+)
+
+// pgErrorKinds maps the SQLSTATE codes to the sentinel errors of the db package.
+var pgErrorKinds = map[string]error{
+	"23505":                      db.ErrUniqueViolation,
+	"23503":                      db.ErrForeignKeyViolation,
+	"23502":                      db.ErrNotNullViolation,
+	"23514":                      db.ErrCheckViolation,
+	sqlStateSerializationFailure: db.ErrSerialization,
+	sqlStateDeadlockDetected:     db.ErrDeadlock,
+}
+
+// IsRetryable reports whether the error is a serialization failure or a deadlock, after which the transaction can be retried.
+func IsRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+
+	return pgErr.Code == sqlStateSerializationFailure || pgErr.Code == sqlStateDeadlockDetected
+}
+
+// classifyError classifies the postgres error by its SQLSTATE, taking the constraint, table and column names from the error fields.
+func classifyError(err error) *db.Error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &db.Error{Kind: db.ErrNoRows, Err: err}
+	}
+
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return nil
+	}
+
+	kind, ok := pgErrorKinds[pgErr.Code]
+	if !ok {
+		return nil
+	}
+
+	return &db.Error{
+		Kind:       kind,
+		Constraint: pgErr.ConstraintName,
+		Table:      pgErr.TableName,
+		Column:     pgErr.ColumnName,
+		Err:        err,
+	}
+}
@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+	"github.com/xsqrty/op/db"
+)
+
+func TestIsRetryable(t *testing.T) {
+	t.Parallel()
+	require.True(t, IsRetryable(&pgconn.PgError{Code: "40001"}))
+	require.True(t, IsRetryable(fmt.Errorf("commit: %w", &pgconn.PgError{Code: "40P01"})))
+	require.False(t, IsRetryable(&pgconn.PgError{Code: "23505"}))
+	require.False(t, IsRetryable(errors.New("40001")))
+}
+
+func TestClassifyError(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name     string
+		err      error
+		expected *db.Error
+	}{
+		{
+			name: "unique",
+			err:  &pgconn.PgError{Code: "23505", ConstraintName: "users_email_key", TableName: "users"},
+			expected: &db.Error{
+				Kind:       db.ErrUniqueViolation,
+				Constraint: "users_email_key",
+				Table:      "users",
+			},
+		},
+		{
+			name:     "foreign_key",
+			err:      &pgconn.PgError{Code: "23503", ConstraintName: "orders_user_id_fkey", TableName: "orders"},
+			expected: &db.Error{Kind: db.ErrForeignKeyViolation, Constraint: "orders_user_id_fkey", Table: "orders"},
+		},
+		{
+			name:     "not_null",
+			err:      &pgconn.PgError{Code: "23502", TableName: "users", ColumnName: "name"},
+			expected: &db.Error{Kind: db.ErrNotNullViolation, Table: "users", Column: "name"},
+		},
+		{
+			name:     "check",
+			err:      &pgconn.PgError{Code: "23514", ConstraintName: "users_age_check", TableName: "users"},
+			expected: &db.Error{Kind: db.ErrCheckViolation, Constraint: "users_age_check", Table: "users"},
+		},
+		{
+			name:     "serialization",
+			err:      &pgconn.PgError{Code: "40001"},
+			expected: &db.Error{Kind: db.ErrSerialization},
+		},
+		{
+			name:     "deadlock",
+			err:      &pgconn.PgError{Code: "40P01"},
+			expected: &db.Error{Kind: db.ErrDeadlock},
+		},
+		{
+			name:     "no_rows",
+			err:      pgx.ErrNoRows,
+			expected: &db.Error{Kind: db.ErrNoRows},
+		},
+		{
+			name:     "unknown_code",
+			err:      &pgconn.PgError{Code: "42P01"},
+			expected: nil,
+		},
+		{
+			name:     "unknown",
+			err:      errors.New("unknown"),
+			expected: nil,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			if c.expected != nil {
+				c.expected.Err = c.err
+			}
+
+			require.Equal(t, c.expected, classifyError(c.err))
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 // NewSqlOptions creates a new instance of SqlOptions with predefined configurations for postgres.
 func NewSqlOptions() *driver.SqlOptions {
 	return driver.NewSqlOptions(
+		driver.WithDialect(driver.DialectPostgres),
 		driver.WithSafeColumns(),
 		driver.WithColumnsDelim('.'),
 		driver.WithFieldsDelim(','),
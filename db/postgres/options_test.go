@@ -16,4 +16,5 @@ func TestNewSqlOptions(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, "$1::INTEGER", cast)
 	require.Equal(t, []any{1}, args)
+	require.Equal(t, driver.DialectPostgres, options.Dialect)
 }
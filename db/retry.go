@@ -0,0 +1,84 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// RetryPolicy defines how Transact re-runs the transactions failed with a retryable error,
+// such as a serialization failure or a deadlock.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one. Values less than 2 disable the retries.
+	MaxAttempts int
+	// Backoff is the delay before the first retry, it is doubled for each next retry.
+	Backoff time.Duration
+	// MaxBackoff limits the delay between the retries. Zero means no limit.
+	MaxBackoff time.Duration
+	// Jitter is the fraction of the delay in the range [0, 1] that is randomly subtracted from it,
+	// which spreads the retries of the concurrent transactions.
+	Jitter float64
+	// Retryable reports whether the error is retryable. Overrides the error classification of the adapter if set.
+	Retryable func(err error) bool
+	// OnAttempt is called after each attempt with its number, starting from 1, and its error, nil on success.
+	OnAttempt func(ctx context.Context, attempt int, err error)
+}
+
+// WithRetry sets the policy re-running the transaction with its handler when it fails with a retryable error.
+// The retries are disabled for the nested transactions, which can't be retried apart from the outer transaction.
+func WithRetry(policy RetryPolicy) TxOption {
+	return func(o *TxOptions) {
+		o.Retry = &policy
+	}
+}
+
+// Do runs the attempt until it succeeds, returns a non-retryable error or the attempts are exhausted.
+// The retryable function is used to classify the errors if the policy has no Retryable function.
+// Waiting between the attempts is interrupted by the cancellation of the context.
+func (p *RetryPolicy) Do(ctx context.Context, retryable func(err error) bool, attempt func() error) error {
+	if p.Retryable != nil {
+		retryable = p.Retryable
+	}
+
+	for n := 1; ; n++ {
+		err := attempt()
+		if p.OnAttempt != nil {
+			p.OnAttempt(ctx, n, err)
+		}
+
+		if err == nil || n >= p.MaxAttempts || retryable == nil || !retryable(err) {
+			return err
+		}
+
+		timer := time.NewTimer(p.delay(n))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return errors.Join(err, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// delay returns the delay after the attempt with the given number, applying the exponential backoff and the jitter.
+func (p *RetryPolicy) delay(attempt int) time.Duration {
+	delay := p.Backoff
+	for i := 1; i < attempt && delay < math.MaxInt64/2; i++ {
+		delay *= 2
+		if p.MaxBackoff > 0 && delay >= p.MaxBackoff {
+			break
+		}
+	}
+
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+
+	if p.Jitter > 0 {
+		delay -= time.Duration(rand.Float64() * min(p.Jitter, 1) * float64(delay)) // nolint: gosec
+	}
+
+	return delay
+}
@@ -0,0 +1,135 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	errRetryable = errors.New("retryable")
+	errFatal     = errors.New("fatal")
+)
+
+func isRetryable(err error) bool {
+	return errors.Is(err, errRetryable)
+}
+
+func TestRetryPolicyDo(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name      string
+		policy    RetryPolicy
+		results   []error
+		expected  error
+		attempts  []int
+		retryable func(err error) bool
+	}{
+		{
+			name:      "success",
+			policy:    RetryPolicy{MaxAttempts: 3},
+			results:   []error{nil},
+			expected:  nil,
+			attempts:  []int{1},
+			retryable: isRetryable,
+		},
+		{
+			name:      "retry_success",
+			policy:    RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond, Jitter: 0.5},
+			results:   []error{errRetryable, errRetryable, nil},
+			expected:  nil,
+			attempts:  []int{1, 2, 3},
+			retryable: isRetryable,
+		},
+		{
+			name:      "exhausted",
+			policy:    RetryPolicy{MaxAttempts: 2},
+			results:   []error{errRetryable, errRetryable},
+			expected:  errRetryable,
+			attempts:  []int{1, 2},
+			retryable: isRetryable,
+		},
+		{
+			name:      "not_retryable",
+			policy:    RetryPolicy{MaxAttempts: 3},
+			results:   []error{errFatal},
+			expected:  errFatal,
+			attempts:  []int{1},
+			retryable: isRetryable,
+		},
+		{
+			name:      "no_classification",
+			policy:    RetryPolicy{MaxAttempts: 3},
+			results:   []error{errRetryable},
+			expected:  errRetryable,
+			attempts:  []int{1},
+			retryable: nil,
+		},
+		{
+			name: "policy_classification",
+			policy: RetryPolicy{MaxAttempts: 3, Retryable: func(err error) bool {
+				return errors.Is(err, errFatal)
+			}},
+			results:   []error{errFatal, nil},
+			expected:  nil,
+			attempts:  []int{1, 2},
+			retryable: isRetryable,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			var attempts []int
+			c.policy.OnAttempt = func(ctx context.Context, attempt int, err error) {
+				require.Equal(t, c.results[attempt-1], err)
+				attempts = append(attempts, attempt)
+			}
+
+			calls := 0
+			err := c.policy.Do(context.Background(), c.retryable, func() error {
+				calls++
+				return c.results[calls-1]
+			})
+
+			require.Equal(t, c.expected, err)
+			require.Equal(t, c.attempts, attempts)
+		})
+	}
+}
+
+func TestRetryPolicyDoCanceled(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	policy := RetryPolicy{MaxAttempts: 3, Backoff: time.Hour}
+	err := policy.Do(ctx, isRetryable, func() error {
+		cancel()
+		return errRetryable
+	})
+
+	require.ErrorIs(t, err, errRetryable)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	t.Parallel()
+	policy := RetryPolicy{Backoff: 10 * time.Millisecond, MaxBackoff: 50 * time.Millisecond}
+	require.Equal(t, 10*time.Millisecond, policy.delay(1))
+	require.Equal(t, 20*time.Millisecond, policy.delay(2))
+	require.Equal(t, 40*time.Millisecond, policy.delay(3))
+	require.Equal(t, 50*time.Millisecond, policy.delay(4))
+	require.Equal(t, 50*time.Millisecond, policy.delay(100))
+
+	policy = RetryPolicy{Backoff: time.Second}
+	require.Positive(t, policy.delay(100))
+
+	policy = RetryPolicy{Backoff: 10 * time.Millisecond, Jitter: 0.5}
+	for range 100 {
+		delay := policy.delay(1)
+		require.GreaterOrEqual(t, delay, 5*time.Millisecond)
+		require.LessOrEqual(t, delay, 10*time.Millisecond)
+	}
+}
@@ -0,0 +1,62 @@
+package sqlite
+
+import (
+	"context"
+	sqldriver "database/sql/driver"
+	"errors"
+)
+
+// connector opens the connections of the sqlite driver and configures each of them
+// with the PRAGMA statements and the after connect hooks.
+type connector struct {
+	driver       sqldriver.Driver
+	dsn          string
+	pragmas      []string
+	afterConnect []AfterConnect
+}
+
+// Connect opens a new connection and executes the PRAGMA statements and hooks on it.
+// The connection is closed if any of them fails.
+func (c *connector) Connect(ctx context.Context) (sqldriver.Conn, error) {
+	conn, err := c.driver.Open(c.dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pragma := range c.pragmas {
+		if err = execConn(ctx, conn, "PRAGMA "+pragma); err != nil {
+			return nil, errors.Join(err, conn.Close())
+		}
+	}
+
+	for _, hook := range c.afterConnect {
+		if err = hook(ctx, conn); err != nil {
+			return nil, errors.Join(err, conn.Close())
+		}
+	}
+
+	return conn, nil
+}
+
+// Driver returns the underlying sqlite driver.
+func (c *connector) Driver() sqldriver.Driver {
+	return c.driver
+}
+
+// execConn executes the statement without arguments on the driver connection.
+func execConn(ctx context.Context, conn sqldriver.Conn, query string) error {
+	if execer, ok := conn.(sqldriver.ExecerContext); ok {
+		_, err := execer.ExecContext(ctx, query, nil)
+		if !errors.Is(err, sqldriver.ErrSkip) {
+			return err
+		}
+	}
+
+	stmt, err := conn.Prepare(query)
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt.Exec(nil) // nolint: staticcheck
+	return errors.Join(err, stmt.Close())
+}
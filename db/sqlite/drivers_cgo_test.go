@@ -0,0 +1,6 @@
+//go:build cgo
+
+package sqlite
+
+// testDrivers are the driver options the tests run with, the cgo driver is available only with cgo.
+var testDrivers = map[string]OpenOption{"cgo": func(*openOptions) {}, "pure_go": WithPureGo()}
@@ -0,0 +1,6 @@
+//go:build !cgo
+
+package sqlite
+
+// testDrivers are the driver options the tests run with, the cgo driver is a stub without cgo.
+var testDrivers = map[string]OpenOption{"pure_go": WithPureGo()}
@@ -0,0 +1,88 @@
+package sqlite
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/xsqrty/op/db"
+	"modernc.org/sqlite"
+	sqlitelib "modernc.org/sqlite/lib"
+)
+
+// errorKinds maps the extended result codes to the sentinel errors of the db package.
+var errorKinds = map[int]error{
+	sqlitelib.SQLITE_CONSTRAINT_UNIQUE:     db.ErrUniqueViolation,
+	sqlitelib.SQLITE_CONSTRAINT_PRIMARYKEY: db.ErrUniqueViolation,
+	sqlitelib.SQLITE_CONSTRAINT_FOREIGNKEY: db.ErrForeignKeyViolation,
+	sqlitelib.SQLITE_CONSTRAINT_NOTNULL:    db.ErrNotNullViolation,
+	sqlitelib.SQLITE_CONSTRAINT_CHECK:      db.ErrCheckViolation,
+}
+
+// IsRetryable reports whether the error is SQLITE_BUSY, returned when the database is locked by another connection.
+// Both the cgo and the pure-Go driver errors are recognized.
+func IsRetryable(err error) bool {
+	code, ok := errorCode(err)
+	return ok && code&0xff == sqlitelib.SQLITE_BUSY
+}
+
+// classifyError classifies the sqlite error by its extended result code, SQLITE_BUSY is classified as db.ErrSerialization.
+// The table and column names are taken from the message, such as "UNIQUE constraint failed: users.email".
+func classifyError(err error) *db.Error {
+	code, ok := errorCode(err)
+	if !ok {
+		return nil
+	}
+
+	if code&0xff == sqlitelib.SQLITE_BUSY {
+		return &db.Error{Kind: db.ErrSerialization, Err: err}
+	}
+
+	kind, ok := errorKinds[code]
+	if !ok {
+		return nil
+	}
+
+	classified := &db.Error{Kind: kind, Err: err}
+	target := constraintTarget(err.Error(), code)
+	if kind == db.ErrCheckViolation {
+		classified.Constraint = target
+		return classified
+	}
+
+	columns := strings.Split(target, ", ")
+	table, column, ok := strings.Cut(columns[0], ".")
+	if ok {
+		classified.Table = table
+		if len(columns) == 1 {
+			classified.Column = column
+		}
+	}
+
+	return classified
+}
+
+// errorCode returns the extended result code of the cgo or the pure-Go driver error.
+func errorCode(err error) (int, bool) {
+	if code, ok := cgoErrorCode(err); ok {
+		return code, true
+	}
+
+	var pureGoErr *sqlite.Error
+	if errors.As(err, &pureGoErr) {
+		return pureGoErr.Code(), true
+	}
+
+	return 0, false
+}
+
+// constraintTarget returns the part of the constraint message following "constraint failed: ".
+// The result code appended by the pure-Go driver, such as " (2067)", is trimmed.
+func constraintTarget(msg string, code int) string {
+	_, target, ok := strings.Cut(strings.TrimSuffix(msg, " ("+strconv.Itoa(code)+")"), " constraint failed: ")
+	if !ok {
+		return ""
+	}
+
+	return target
+}
@@ -0,0 +1,19 @@
+//go:build cgo
+
+package sqlite
+
+import (
+	"errors"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// cgoErrorCode returns the extended result code of the cgo driver error.
+func cgoErrorCode(err error) (int, bool) {
+	var cgoErr sqlite3.Error
+	if errors.As(err, &cgoErr) {
+		return int(cgoErr.ExtendedCode), true
+	}
+
+	return 0, false
+}
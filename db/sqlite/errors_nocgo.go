@@ -0,0 +1,8 @@
+//go:build !cgo
+
+package sqlite
+
+// cgoErrorCode reports no code, the cgo driver is not available without cgo.
+func cgoErrorCode(error) (int, bool) {
+	return 0, false
+}
@@ -0,0 +1,97 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xsqrty/op/db"
+)
+
+func TestClassifyError(t *testing.T) {
+	t.Parallel()
+	for name, driverOption := range testDrivers {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			pool, err := Open(":memory:", driverOption, WithMaxOpenConns(1), WithForeignKeys(true))
+			require.NoError(t, err)
+			defer pool.Close() // nolint: errcheck
+
+			_, err = pool.Exec(ctx, `CREATE TABLE users (
+				id INTEGER PRIMARY KEY,
+				email TEXT NOT NULL UNIQUE,
+				age INTEGER CONSTRAINT users_age_check CHECK (age >= 0)
+			)`)
+			require.NoError(t, err)
+			_, err = pool.Exec(ctx, "CREATE TABLE orders (id INTEGER PRIMARY KEY, user_id INTEGER REFERENCES users (id))")
+			require.NoError(t, err)
+			_, err = pool.Exec(ctx, "INSERT INTO users (id, email, age) VALUES (1, 'alex@example.com', 20)")
+			require.NoError(t, err)
+
+			cases := []struct {
+				name     string
+				sql      string
+				expected db.Error
+			}{
+				{
+					name:     "unique",
+					sql:      "INSERT INTO users (email) VALUES ('alex@example.com')",
+					expected: db.Error{Kind: db.ErrUniqueViolation, Table: "users", Column: "email"},
+				},
+				{
+					name:     "primary_key",
+					sql:      "INSERT INTO users (id, email) VALUES (1, 'bob@example.com')",
+					expected: db.Error{Kind: db.ErrUniqueViolation, Table: "users", Column: "id"},
+				},
+				{
+					name:     "not_null",
+					sql:      "INSERT INTO users (email) VALUES (NULL)",
+					expected: db.Error{Kind: db.ErrNotNullViolation, Table: "users", Column: "email"},
+				},
+				{
+					name:     "check",
+					sql:      "INSERT INTO users (email, age) VALUES ('bob@example.com', -1)",
+					expected: db.Error{Kind: db.ErrCheckViolation, Constraint: "users_age_check"},
+				},
+				{
+					name:     "foreign_key",
+					sql:      "INSERT INTO orders (user_id) VALUES (2)",
+					expected: db.Error{Kind: db.ErrForeignKeyViolation},
+				},
+			}
+
+			for _, c := range cases {
+				_, err := pool.Exec(ctx, c.sql)
+				require.ErrorIs(t, err, c.expected.Kind, c.name)
+
+				var classified *db.Error
+				require.ErrorAs(t, err, &classified, c.name)
+				c.expected.Err = classified.Err
+				require.Equal(t, &c.expected, classified, c.name)
+			}
+
+			err = pool.Transact(ctx, func(ctx context.Context) error {
+				_, err := pool.Exec(ctx, "INSERT INTO users (email) VALUES ('alex@example.com')")
+				return err
+			})
+			require.ErrorIs(t, err, db.ErrUniqueViolation)
+
+			var id int
+			err = pool.QueryRow(ctx, "SELECT id FROM users WHERE email = $1", "bob@example.com").Scan(&id)
+			require.ErrorIs(t, err, db.ErrNoRows)
+			require.ErrorIs(t, err, sql.ErrNoRows)
+		})
+	}
+}
+
+func TestConstraintTarget(t *testing.T) {
+	t.Parallel()
+	require.Equal(t, "users.email", constraintTarget("UNIQUE constraint failed: users.email", 2067))
+	require.Equal(t, "users.email", constraintTarget("constraint failed: UNIQUE constraint failed: users.email (2067)", 2067))
+	require.Equal(t, "length(name) > (1)", constraintTarget("CHECK constraint failed: length(name) > (1)", 275))
+	require.Equal(t, "", constraintTarget("FOREIGN KEY constraint failed", 787))
+	require.False(t, IsRetryable(errors.New("UNIQUE constraint failed: users.email")))
+}
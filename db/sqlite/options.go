@@ -9,6 +9,7 @@ import (
 // NewSqlOptions creates a new instance of SqlOptions with predefined configurations for sqlite.
 func NewSqlOptions() *driver.SqlOptions {
 	return driver.NewSqlOptions(
+		driver.WithDialect(driver.DialectSqlite),
 		driver.WithSafeColumns(),
 		driver.WithColumnsDelim('.'),
 		driver.WithFieldsDelim(','),
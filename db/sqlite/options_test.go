@@ -16,4 +16,5 @@ func TestNewSqlOptions(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, "CAST($1 AS INTEGER)", cast)
 	require.Equal(t, []any{1}, args)
+	require.Equal(t, driver.DialectSqlite, options.Dialect)
 }
@@ -1,37 +1,101 @@
 package sqlite
 
 import (
+	"context"
 	"database/sql"
+	sqldriver "database/sql/driver"
+	"strconv"
+	"strings"
 	"time"
 
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/xsqrty/op/db"
+	_ "modernc.org/sqlite"
 )
 
 type (
 	OpenOption func(options *openOptions)
+	// AfterConnect is called after a new connection is opened and configured with the PRAGMA options.
+	// Use it to execute per-connection statements, the connection implements driver.ExecerContext.
+	AfterConnect func(ctx context.Context, conn sqldriver.Conn) error
+	// JournalMode is the value of the journal_mode PRAGMA.
+	JournalMode string
+	// SynchronousMode is the value of the synchronous PRAGMA.
+	SynchronousMode string
+	// TxLock is the locking behavior of the BEGIN statement of the transactions.
+	TxLock string
+)
+
+const (
+	// JournalDelete deletes the rollback journal at the end of each transaction (default).
+	JournalDelete JournalMode = "DELETE"
+	// JournalTruncate truncates the rollback journal instead of deleting it.
+	JournalTruncate JournalMode = "TRUNCATE"
+	// JournalPersist overwrites the header of the rollback journal instead of deleting it.
+	JournalPersist JournalMode = "PERSIST"
+	// JournalMemory stores the rollback journal in memory.
+	JournalMemory JournalMode = "MEMORY"
+	// JournalWal uses the write-ahead log, which allows the readers to work concurrently with a writer.
+	JournalWal JournalMode = "WAL"
+	// JournalOff disables the rollback journal.
+	JournalOff JournalMode = "OFF"
+)
+
+const (
+	// SynchronousOff hands the data to the operating system without syncing.
+	SynchronousOff SynchronousMode = "OFF"
+	// SynchronousNormal syncs at the critical moments, it is safe in the WAL journal mode.
+	SynchronousNormal SynchronousMode = "NORMAL"
+	// SynchronousFull syncs the data before each transaction is committed (default).
+	SynchronousFull SynchronousMode = "FULL"
+	// SynchronousExtra additionally syncs the directory of the rollback journal.
+	SynchronousExtra SynchronousMode = "EXTRA"
+)
+
+const (
+	// TxLockDeferred starts the transaction without locks until the first read or write (default).
+	TxLockDeferred TxLock = "deferred"
+	// TxLockImmediate takes the write lock at the start of the transaction.
+	TxLockImmediate TxLock = "immediate"
+	// TxLockExclusive takes the exclusive lock at the start of the transaction.
+	TxLockExclusive TxLock = "exclusive"
+)
+
+const (
+	// cgoDriverName is the name of the mattn/go-sqlite3 driver, which is used by default.
+	cgoDriverName = "sqlite3"
+	// pureGoDriverName is the name of the modernc.org/sqlite driver.
+	pureGoDriverName = "sqlite"
 )
 
 // openOptions sqlite pool configuration
 type openOptions struct {
+	driverName   string
 	maxIdleCount int
 	maxOpen      int
 	maxLifetime  time.Duration
 	maxIdleTime  time.Duration
+	busyTimeout  time.Duration
+	foreignKeys  *bool
+	journalMode  JournalMode
+	synchronous  SynchronousMode
+	txLock       TxLock
+	pragmas      []string
+	afterConnect []AfterConnect
 }
 
 // Open establishes a connection to the sqlite database.
 func Open(dsn string, options ...OpenOption) (db.ConnPool, error) {
-	pool, err := sql.Open("sqlite3", dsn)
-	if err != nil {
-		return nil, err
-	}
-
-	config := openOptions{}
+	config := openOptions{driverName: cgoDriverName}
 	for _, option := range options {
 		option(&config)
 	}
 
+	pool, err := openDB(dsn, &config)
+	if err != nil {
+		return nil, err
+	}
+
 	if config.maxIdleCount > 0 {
 		pool.SetMaxIdleConns(config.maxIdleCount)
 	}
@@ -56,7 +120,69 @@ func Open(dsn string, options ...OpenOption) (db.ConnPool, error) {
 		return nil, err
 	}
 
-	return db.NewConnPool(pool, NewSqlOptions()), nil
+	return db.NewConnPool(pool, NewSqlOptions(), db.WithRetryable(IsRetryable), db.WithErrorClassifier(classifyError)), nil
+}
+
+// openDB opens the database using the connector, which configures every new connection of the pool.
+func openDB(dsn string, config *openOptions) (*sql.DB, error) {
+	// sql.Open doesn't connect, it resolves the registered driver by name
+	resolver, err := sql.Open(config.driverName, dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlDriver := resolver.Driver()
+	if err = resolver.Close(); err != nil {
+		return nil, err
+	}
+
+	if config.txLock != "" {
+		dsn = withDsnParam(dsn, "_txlock", string(config.txLock))
+	}
+
+	// mattn/go-sqlite3 executes statements with its default 5s busy timeout while it opens the connection
+	if config.busyTimeout > 0 && config.driverName == cgoDriverName {
+		dsn = withDsnParam(dsn, "_busy_timeout", strconv.FormatInt(config.busyTimeout.Milliseconds(), 10))
+	}
+
+	return sql.OpenDB(&connector{
+		driver:       sqlDriver,
+		dsn:          dsn,
+		pragmas:      config.connPragmas(),
+		afterConnect: config.afterConnect,
+	}), nil
+}
+
+// connPragmas returns the PRAGMA statements executed on every new connection.
+// The busy timeout goes first, so the other statements wait for the locks.
+func (o *openOptions) connPragmas() []string {
+	var pragmas []string
+	if o.busyTimeout > 0 {
+		pragmas = append(pragmas, "busy_timeout = "+strconv.FormatInt(o.busyTimeout.Milliseconds(), 10))
+	}
+
+	if o.foreignKeys != nil {
+		pragmas = append(pragmas, "foreign_keys = "+strconv.FormatBool(*o.foreignKeys))
+	}
+
+	if o.journalMode != "" {
+		pragmas = append(pragmas, "journal_mode = "+string(o.journalMode))
+	}
+
+	if o.synchronous != "" {
+		pragmas = append(pragmas, "synchronous = "+string(o.synchronous))
+	}
+
+	return append(pragmas, o.pragmas...)
+}
+
+// withDsnParam appends the query parameter to the dsn.
+func withDsnParam(dsn string, key string, value string) string {
+	if strings.ContainsRune(dsn, '?') {
+		return dsn + "&" + key + "=" + value
+	}
+
+	return dsn + "?" + key + "=" + value
 }
 
 // WithMaxIdleConns sets the maximum number of connections in the idle
@@ -110,3 +236,69 @@ func WithConnMaxIdleTime(d time.Duration) OpenOption {
 		options.maxIdleTime = d
 	}
 }
+
+// WithPureGo opens the database using the pure-Go [modernc.org/sqlite] driver instead of [github.com/mattn/go-sqlite3].
+// The driver doesn't require cgo, so the binaries can be built statically (CGO_ENABLED=0) and cross-compiled.
+//
+// The connection parameters of the dsn are driver specific, e.g. "file:app.db?_pragma=foreign_keys(1)".
+func WithPureGo() OpenOption {
+	return func(options *openOptions) {
+		options.driverName = pureGoDriverName
+	}
+}
+
+// WithJournalMode sets the journal_mode PRAGMA of every connection.
+// The WAL mode allows the readers to work concurrently with a writer.
+func WithJournalMode(mode JournalMode) OpenOption {
+	return func(options *openOptions) {
+		options.journalMode = mode
+	}
+}
+
+// WithSynchronous sets the synchronous PRAGMA of every connection.
+// The NORMAL mode is safe and faster than FULL in the WAL journal mode.
+func WithSynchronous(mode SynchronousMode) OpenOption {
+	return func(options *openOptions) {
+		options.synchronous = mode
+	}
+}
+
+// WithBusyTimeout sets the busy_timeout PRAGMA of every connection, which is the time
+// a statement waits for a locked database before it fails with the "database is locked" error.
+func WithBusyTimeout(d time.Duration) OpenOption {
+	return func(options *openOptions) {
+		options.busyTimeout = d
+	}
+}
+
+// WithForeignKeys sets the foreign_keys PRAGMA of every connection, which enforces the foreign key constraints.
+func WithForeignKeys(enabled bool) OpenOption {
+	return func(options *openOptions) {
+		options.foreignKeys = &enabled
+	}
+}
+
+// WithPragma adds the PRAGMA statement executed on every new connection, such as WithPragma("cache_size", "-20000").
+// The statements are executed after the PRAGMA options in the order they are added.
+func WithPragma(name string, value string) OpenOption {
+	return func(options *openOptions) {
+		options.pragmas = append(options.pragmas, name+" = "+value)
+	}
+}
+
+// WithAfterConnect adds the hook called on every new connection after the PRAGMA statements.
+// If the hook returns an error, the connection is closed and the error is returned.
+func WithAfterConnect(hook AfterConnect) OpenOption {
+	return func(options *openOptions) {
+		options.afterConnect = append(options.afterConnect, hook)
+	}
+}
+
+// WithTxLock sets the locking behavior of the BEGIN statement of the transactions.
+// TxLockImmediate takes the write lock up front (BEGIN IMMEDIATE), so write transactions wait for
+// the busy timeout at the start instead of failing with the "database is locked" error on the first write.
+func WithTxLock(lock TxLock) OpenOption {
+	return func(options *openOptions) {
+		options.txLock = lock
+	}
+}
@@ -0,0 +1,308 @@
+package sqlite
+
+import (
+	"context"
+	sqldriver "database/sql/driver"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xsqrty/op/db"
+)
+
+func TestOpen(t *testing.T) {
+	t.Parallel()
+	for name, driverOption := range testDrivers {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			pool, err := Open(":memory:", driverOption, WithMaxOpenConns(1), WithConnMaxIdleTime(time.Minute))
+			require.NoError(t, err)
+			defer pool.Close() // nolint: errcheck
+
+			_, err = pool.Exec(ctx, "CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT NOT NULL, created_at DATETIME NOT NULL)")
+			require.NoError(t, err)
+
+			createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+			res, err := pool.Exec(ctx, "INSERT INTO users (name, created_at) VALUES ($1, $2)", "Alex", createdAt)
+			require.NoError(t, err)
+
+			id, err := res.LastInsertId()
+			require.NoError(t, err)
+			require.Equal(t, int64(1), id)
+
+			var name string
+			var fromDb time.Time
+			err = pool.QueryRow(ctx, "SELECT name, created_at FROM users WHERE id = $1", id).Scan(&name, &fromDb)
+			require.NoError(t, err)
+			require.Equal(t, "Alex", name)
+			require.True(t, createdAt.Equal(fromDb))
+		})
+	}
+}
+
+func TestOpenError(t *testing.T) {
+	t.Parallel()
+	_, err := Open("file:/not/exists/dir/storage.db?mode=ro", WithPureGo())
+	require.Error(t, err)
+}
+
+func TestOpenPragmas(t *testing.T) {
+	t.Parallel()
+	for name, driverOption := range testDrivers {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			connected := 0
+			pool, err := Open(
+				filepath.Join(t.TempDir(), "storage.db"),
+				driverOption,
+				WithJournalMode(JournalWal),
+				WithSynchronous(SynchronousNormal),
+				WithBusyTimeout(3*time.Second),
+				WithForeignKeys(true),
+				WithPragma("cache_size", "-4000"),
+				WithAfterConnect(func(ctx context.Context, conn sqldriver.Conn) error {
+					connected++
+					return nil
+				}),
+			)
+			require.NoError(t, err)
+			defer pool.Close() // nolint: errcheck
+
+			var journalMode string
+			var synchronous, busyTimeout, foreignKeys, cacheSize int
+			err = pool.QueryRow(ctx, `SELECT
+				(SELECT journal_mode FROM pragma_journal_mode),
+				(SELECT synchronous FROM pragma_synchronous),
+				(SELECT timeout FROM pragma_busy_timeout),
+				(SELECT foreign_keys FROM pragma_foreign_keys),
+				(SELECT cache_size FROM pragma_cache_size)`,
+			).Scan(&journalMode, &synchronous, &busyTimeout, &foreignKeys, &cacheSize)
+			require.NoError(t, err)
+
+			require.Equal(t, "wal", journalMode)
+			require.Equal(t, 1, synchronous)
+			require.Equal(t, 3000, busyTimeout)
+			require.Equal(t, 1, foreignKeys)
+			require.Equal(t, -4000, cacheSize)
+			require.Equal(t, 1, connected)
+		})
+	}
+}
+
+func TestOpenAfterConnectError(t *testing.T) {
+	t.Parallel()
+	for name, driverOption := range testDrivers {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			_, err := Open(":memory:", driverOption, WithAfterConnect(func(ctx context.Context, conn sqldriver.Conn) error {
+				return context.Canceled
+			}))
+			require.ErrorIs(t, err, context.Canceled)
+		})
+	}
+}
+
+func TestOpenTxLock(t *testing.T) {
+	t.Parallel()
+	for name, driverOption := range testDrivers {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			dsn := filepath.Join(t.TempDir(), "storage.db")
+
+			for _, lock := range []TxLock{TxLockDeferred, TxLockImmediate, TxLockExclusive} {
+				pool, err := Open(dsn, driverOption, WithTxLock(lock), WithBusyTimeout(10*time.Millisecond))
+				require.NoError(t, err)
+
+				err = pool.Transact(ctx, func(ctx context.Context) error {
+					// the transaction of another connection
+					return pool.Transact(context.Background(), func(ctx context.Context) error {
+						return nil
+					})
+				})
+
+				if lock == TxLockDeferred {
+					require.NoError(t, err)
+				} else {
+					require.ErrorContains(t, err, "database is locked")
+				}
+
+				require.NoError(t, pool.Close())
+			}
+		})
+	}
+}
+
+func TestTransactSavepoint(t *testing.T) {
+	t.Parallel()
+	errInner := errors.New("inner")
+	for name, driverOption := range testDrivers {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			pool, err := Open(filepath.Join(t.TempDir(), "storage.db"), driverOption)
+			require.NoError(t, err)
+			defer pool.Close() // nolint: errcheck
+
+			_, err = pool.Exec(ctx, "CREATE TABLE items (name TEXT NOT NULL)")
+			require.NoError(t, err)
+
+			insert := func(name string) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					_, err := pool.Exec(ctx, "INSERT INTO items (name) VALUES ($1)", name)
+					return err
+				}
+			}
+
+			require.NoError(t, pool.Transact(ctx, func(ctx context.Context) error {
+				require.NoError(t, insert("outer")(ctx))
+				require.NoError(t, pool.Transact(ctx, func(ctx context.Context) error {
+					require.NoError(t, insert("released")(ctx))
+					return pool.Transact(ctx, func(ctx context.Context) error {
+						require.NoError(t, insert("nested")(ctx))
+						return nil
+					})
+				}))
+
+				require.ErrorIs(t, pool.Transact(ctx, func(ctx context.Context) error {
+					require.NoError(t, insert("rolled_back")(ctx))
+					require.NoError(t, pool.Transact(ctx, insert("rolled_back_nested")))
+					return errInner
+				}), errInner)
+
+				return nil
+			}))
+
+			rows, err := pool.Query(ctx, "SELECT name FROM items ORDER BY rowid")
+			require.NoError(t, err)
+			defer rows.Close()
+
+			var names []string
+			for _, row := range rows.Rows() {
+				var name string
+				require.NoError(t, row.Scan(&name))
+				names = append(names, name)
+			}
+
+			require.NoError(t, rows.Err())
+			require.Equal(t, []string{"outer", "released", "nested"}, names)
+		})
+	}
+}
+
+func TestTransactReadOnly(t *testing.T) {
+	t.Parallel()
+	for name, driverOption := range testDrivers {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			pool, err := Open(filepath.Join(t.TempDir(), "storage.db"), driverOption, WithMaxOpenConns(1))
+			require.NoError(t, err)
+			defer pool.Close() // nolint: errcheck
+
+			_, err = pool.Exec(ctx, "CREATE TABLE items (name TEXT NOT NULL)")
+			require.NoError(t, err)
+
+			require.Error(t, pool.Transact(ctx, func(ctx context.Context) error {
+				var count int
+				require.NoError(t, pool.QueryRow(ctx, "SELECT COUNT(*) FROM items").Scan(&count))
+				_, err := pool.Exec(ctx, "INSERT INTO items (name) VALUES ($1)", "read_only")
+				return err
+			}, db.WithReadOnly()))
+
+			_, err = pool.Exec(ctx, "INSERT INTO items (name) VALUES ($1)", "writable")
+			require.NoError(t, err)
+
+			var name string
+			require.NoError(t, pool.Transact(ctx, func(ctx context.Context) error {
+				return pool.QueryRow(ctx, "SELECT name FROM items").Scan(&name)
+			}, db.WithReadOnly()))
+			require.Equal(t, "writable", name)
+		})
+	}
+}
+
+func TestTransactRetry(t *testing.T) {
+	t.Parallel()
+	for name, driverOption := range testDrivers {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			pool, err := Open(filepath.Join(t.TempDir(), "storage.db"), driverOption, WithTxLock(TxLockImmediate), WithBusyTimeout(10*time.Millisecond))
+			require.NoError(t, err)
+			defer pool.Close() // nolint: errcheck
+
+			var attempts []int
+			policy := db.RetryPolicy{
+				MaxAttempts: 3,
+				Backoff:     time.Millisecond,
+				OnAttempt: func(ctx context.Context, attempt int, err error) {
+					require.True(t, IsRetryable(err))
+					attempts = append(attempts, attempt)
+				},
+			}
+
+			err = pool.Transact(ctx, func(ctx context.Context) error {
+				// the transaction of another connection
+				return pool.Transact(context.Background(), func(ctx context.Context) error {
+					return nil
+				}, db.WithRetry(policy))
+			})
+
+			require.True(t, IsRetryable(err))
+			require.Equal(t, []int{1, 2, 3}, attempts)
+			require.False(t, IsRetryable(errors.New("database is locked")))
+		})
+	}
+}
+
+func TestTransactHooks(t *testing.T) {
+	t.Parallel()
+	for name, driverOption := range testDrivers {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			pool, err := Open(":memory:", driverOption, WithMaxOpenConns(1))
+			require.NoError(t, err)
+			defer pool.Close() // nolint: errcheck
+
+			_, err = pool.Exec(ctx, "CREATE TABLE items (name TEXT NOT NULL UNIQUE)")
+			require.NoError(t, err)
+
+			var calls []string
+			register := func(ctx context.Context, name string) {
+				db.AfterCommit(ctx, func(ctx context.Context) {
+					calls = append(calls, name+"_commit")
+				})
+				db.AfterRollback(ctx, func(ctx context.Context) {
+					calls = append(calls, name+"_rollback")
+				})
+			}
+
+			insert := func(name string) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					register(ctx, name)
+					_, err := pool.Exec(ctx, "INSERT INTO items (name) VALUES ($1)", name)
+					return err
+				}
+			}
+
+			require.NoError(t, pool.Transact(ctx, func(ctx context.Context) error {
+				require.NoError(t, insert("outer")(ctx))
+				require.NoError(t, pool.Transact(ctx, insert("released")))
+				require.Error(t, pool.Transact(ctx, insert("outer")))
+				require.Equal(t, []string{"outer_rollback"}, calls)
+				return nil
+			}))
+			require.Equal(t, []string{"outer_rollback", "outer_commit", "released_commit"}, calls)
+
+			calls = nil
+			require.Error(t, pool.Transact(ctx, insert("outer")))
+			require.Equal(t, []string{"outer_rollback"}, calls)
+		})
+	}
+}
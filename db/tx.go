@@ -0,0 +1,53 @@
+package db
+
+import (
+	"database/sql"
+)
+
+// TxOptions holds the options of a transaction started by Transacter.Transact.
+type TxOptions struct {
+	// Isolation is the isolation level of the transaction. sql.LevelDefault uses the default level of the database.
+	Isolation sql.IsolationLevel
+	// ReadOnly makes the transaction reject the data modifications.
+	// The sqlite drivers ignore the option, so the connection is switched to PRAGMA query_only for the length of the transaction.
+	ReadOnly bool
+	// Deferrable makes a serializable read-only transaction wait for a snapshot that cannot cause serialization failures.
+	// Only postgres has deferrable transactions, the database/sql adapter ignores the option.
+	Deferrable bool
+	// Retry is the policy re-running the failed transaction, nil disables the retries.
+	Retry *RetryPolicy
+}
+
+// TxOption defines a function type for configuring the options of a transaction.
+type TxOption func(*TxOptions)
+
+// NewTxOptions returns the transaction options modified by the given options. The default isolation level is ReadCommitted.
+func NewTxOptions(opts ...TxOption) *TxOptions {
+	options := &TxOptions{Isolation: sql.LevelReadCommitted}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	return options
+}
+
+// WithIsolation sets the isolation level of the transaction, such as sql.LevelSerializable or sql.LevelRepeatableRead.
+func WithIsolation(level sql.IsolationLevel) TxOption {
+	return func(o *TxOptions) {
+		o.Isolation = level
+	}
+}
+
+// WithReadOnly makes the transaction read-only.
+func WithReadOnly() TxOption {
+	return func(o *TxOptions) {
+		o.ReadOnly = true
+	}
+}
+
+// WithDeferrable makes the transaction deferrable. It takes effect in postgres for serializable read-only transactions.
+func WithDeferrable() TxOption {
+	return func(o *TxOptions) {
+		o.Deferrable = true
+	}
+}
@@ -0,0 +1,776 @@
+package op
+
+import (
+	sqldriver "database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xsqrty/op/driver"
+)
+
+// ColumnType defines an interface for the data type of the column, rendered according to the dialect of SqlOptions.
+type ColumnType interface {
+	// Sql generates the name of the data type for the dialect of the provided SqlOptions.
+	Sql(options *driver.SqlOptions) (string, []any, error)
+}
+
+// ColumnDefBuilder provides an interface for building column definitions of CREATE TABLE and ALTER TABLE statements.
+type ColumnDefBuilder interface {
+	// NotNull adds the NOT NULL constraint to the column.
+	NotNull() ColumnDefBuilder
+	// Default sets the default value of the column. The value can be a literal or an expression implementing Sqler.
+	Default(value any) ColumnDefBuilder
+	// PrimaryKey marks the column as the primary key of the table.
+	PrimaryKey() ColumnDefBuilder
+	// Unique adds the UNIQUE constraint to the column.
+	Unique() ColumnDefBuilder
+	// Check adds the CHECK constraint with the specified expression to the column.
+	Check(exp driver.Sqler) ColumnDefBuilder
+	// References adds the foreign key constraint referencing the column of the specified table.
+	References(table string, column string) ColumnDefBuilder
+	// OnDelete sets the action performed when the referenced row is deleted.
+	OnDelete(action ReferenceAction) ColumnDefBuilder
+	// OnUpdate sets the action performed when the referenced row is updated.
+	OnUpdate(action ReferenceAction) ColumnDefBuilder
+	// Sql generates the column definition as an SQL string with its arguments based on the provided SqlOptions.
+	Sql(options *driver.SqlOptions) (string, []any, error)
+}
+
+// ConstraintBuilder provides an interface for building table constraints, such as PRIMARY KEY, UNIQUE and CHECK.
+type ConstraintBuilder interface {
+	// Named sets the name of the constraint.
+	Named(name string) ConstraintBuilder
+	// Sql generates the table constraint as an SQL string with its arguments based on the provided SqlOptions.
+	Sql(options *driver.SqlOptions) (string, []any, error)
+}
+
+// ForeignKeyBuilder provides an interface for building FOREIGN KEY table constraints.
+type ForeignKeyBuilder interface {
+	// Named sets the name of the constraint.
+	Named(name string) ForeignKeyBuilder
+	// References sets the referenced table and its columns.
+	References(table string, columns ...string) ForeignKeyBuilder
+	// OnDelete sets the action performed when the referenced row is deleted.
+	OnDelete(action ReferenceAction) ForeignKeyBuilder
+	// OnUpdate sets the action performed when the referenced row is updated.
+	OnUpdate(action ReferenceAction) ForeignKeyBuilder
+	// Sql generates the table constraint as an SQL string with its arguments based on the provided SqlOptions.
+	Sql(options *driver.SqlOptions) (string, []any, error)
+}
+
+// Constraint defines an interface for table constraints used by CREATE TABLE and ALTER TABLE statements.
+type Constraint interface {
+	// Sql generates the table constraint as an SQL string with its arguments based on the provided SqlOptions.
+	Sql(options *driver.SqlOptions) (string, []any, error)
+}
+
+type (
+	// ReferenceAction defines the action performed on the referencing rows when the referenced row is deleted or updated.
+	ReferenceAction int
+	// constraintType defines the type of the table constraint.
+	constraintType int
+)
+
+// columnType represents the data type with the default name and dialect-specific names.
+type columnType struct {
+	name     string
+	dialects map[driver.Dialect]string
+}
+
+// arrayType represents the array data type of the specified element type.
+type arrayType struct {
+	elem ColumnType
+}
+
+// columnDef represents the column definition with its data type and constraints.
+type columnDef struct {
+	name       Column
+	typ        ColumnType
+	notNull    bool
+	primaryKey bool
+	unique     bool
+	hasDefault bool
+	def        any
+	checks     []driver.Sqler
+	reference  *reference
+}
+
+// reference represents the referenced table and columns of the foreign key with its actions.
+type reference struct {
+	table    Column
+	columns  []Column
+	onDelete ReferenceAction
+	onUpdate ReferenceAction
+}
+
+// constraint represents a named or unnamed PRIMARY KEY, UNIQUE or CHECK table constraint.
+type constraint struct {
+	name           Column
+	constraintType constraintType
+	columns        []Column
+	check          driver.Sqler
+}
+
+// foreignKey represents a named or unnamed FOREIGN KEY table constraint.
+type foreignKey struct {
+	name      Column
+	columns   []Column
+	reference reference
+}
+
+// ReferenceNoAction represents the default NO ACTION behavior, which is not rendered.
+// ReferenceRestrict represents the RESTRICT action.
+// ReferenceCascade represents the CASCADE action.
+// ReferenceSetNull represents the SET NULL action.
+// ReferenceSetDefault represents the SET DEFAULT action.
+const (
+	ReferenceNoAction ReferenceAction = iota
+	ReferenceRestrict
+	ReferenceCascade
+	ReferenceSetNull
+	ReferenceSetDefault
+)
+
+// constraintPrimaryKey represents the PRIMARY KEY constraint.
+// constraintUnique represents the UNIQUE constraint.
+// constraintCheck represents the CHECK constraint.
+const (
+	constraintPrimaryKey constraintType = iota
+	constraintUnique
+	constraintCheck
+)
+
+// Type creates a ColumnType with the specified name, rendered as is for all dialects.
+func Type(name string) ColumnType {
+	return &columnType{name: name}
+}
+
+// TypeSmallInt creates a 2-byte integer ColumnType.
+func TypeSmallInt() ColumnType {
+	return &columnType{name: "SMALLINT", dialects: map[driver.Dialect]string{driver.DialectSqlite: "INTEGER"}}
+}
+
+// TypeInteger creates a 4-byte integer ColumnType.
+func TypeInteger() ColumnType {
+	return &columnType{name: "INTEGER"}
+}
+
+// TypeBigInt creates an 8-byte integer ColumnType.
+func TypeBigInt() ColumnType {
+	return &columnType{name: "BIGINT", dialects: map[driver.Dialect]string{driver.DialectSqlite: "INTEGER"}}
+}
+
+// TypeSerial creates an auto-incrementing 4-byte integer ColumnType.
+// In sqlite, the INTEGER PRIMARY KEY column is auto-incrementing, mysql and mssql render the auto-incrementing INT.
+func TypeSerial() ColumnType {
+	return &columnType{name: "SERIAL", dialects: map[driver.Dialect]string{
+		driver.DialectSqlite: "INTEGER",
+		driver.DialectMysql:  "INT AUTO_INCREMENT",
+		driver.DialectMssql:  "INT IDENTITY(1,1)",
+	}}
+}
+
+// TypeBigSerial creates an auto-incrementing 8-byte integer ColumnType.
+// In sqlite, the INTEGER PRIMARY KEY column is auto-incrementing, mysql and mssql render the auto-incrementing BIGINT.
+func TypeBigSerial() ColumnType {
+	return &columnType{name: "BIGSERIAL", dialects: map[driver.Dialect]string{
+		driver.DialectSqlite: "INTEGER",
+		driver.DialectMysql:  "BIGINT AUTO_INCREMENT",
+		driver.DialectMssql:  "BIGINT IDENTITY(1,1)",
+	}}
+}
+
+// TypeReal creates a single precision floating-point ColumnType.
+func TypeReal() ColumnType {
+	return &columnType{name: "REAL"}
+}
+
+// TypeDouble creates a double precision floating-point ColumnType.
+func TypeDouble() ColumnType {
+	return &columnType{name: "DOUBLE PRECISION", dialects: map[driver.Dialect]string{driver.DialectSqlite: "REAL"}}
+}
+
+// TypeNumeric creates an exact numeric ColumnType with the specified precision and scale.
+// If the precision is zero, the precision and scale are omitted.
+func TypeNumeric(precision, scale uint) ColumnType {
+	name := "NUMERIC"
+	if precision > 0 {
+		name += "(" + strconv.FormatUint(uint64(precision), 10) + "," + strconv.FormatUint(uint64(scale), 10) + ")"
+	}
+
+	return &columnType{name: name, dialects: map[driver.Dialect]string{driver.DialectSqlite: "NUMERIC"}}
+}
+
+// TypeBoolean creates a boolean ColumnType.
+func TypeBoolean() ColumnType {
+	return &columnType{name: "BOOLEAN", dialects: map[driver.Dialect]string{driver.DialectMssql: "BIT"}}
+}
+
+// TypeText creates a variable unlimited length text ColumnType.
+func TypeText() ColumnType {
+	return &columnType{name: "TEXT", dialects: map[driver.Dialect]string{driver.DialectMssql: "NVARCHAR(MAX)"}}
+}
+
+// TypeVarchar creates a variable length text ColumnType with the specified length limit.
+func TypeVarchar(length uint) ColumnType {
+	return &columnType{
+		name:     "VARCHAR(" + strconv.FormatUint(uint64(length), 10) + ")",
+		dialects: map[driver.Dialect]string{driver.DialectSqlite: "TEXT"},
+	}
+}
+
+// TypeBytes creates a binary data ColumnType.
+func TypeBytes() ColumnType {
+	return &columnType{name: "BYTEA", dialects: map[driver.Dialect]string{
+		driver.DialectSqlite: "BLOB",
+		driver.DialectMysql:  "BLOB",
+		driver.DialectMssql:  "VARBINARY(MAX)",
+	}}
+}
+
+// TypeDate creates a calendar date ColumnType.
+func TypeDate() ColumnType {
+	return &columnType{name: "DATE"}
+}
+
+// TypeTimestamp creates a date and time ColumnType without time zone.
+func TypeTimestamp() ColumnType {
+	return &columnType{name: "TIMESTAMP", dialects: map[driver.Dialect]string{
+		driver.DialectSqlite: "DATETIME",
+		driver.DialectMssql:  "DATETIME2",
+	}}
+}
+
+// TypeTimestampTz creates a date and time ColumnType with time zone.
+func TypeTimestampTz() ColumnType {
+	return &columnType{name: "TIMESTAMPTZ", dialects: map[driver.Dialect]string{
+		driver.DialectSqlite: "DATETIME",
+		driver.DialectMysql:  "TIMESTAMP",
+		driver.DialectMssql:  "DATETIMEOFFSET",
+	}}
+}
+
+// TypeUuid creates a universally unique identifier ColumnType.
+func TypeUuid() ColumnType {
+	return &columnType{name: "UUID", dialects: map[driver.Dialect]string{
+		driver.DialectSqlite: "TEXT",
+		driver.DialectMysql:  "CHAR(36)",
+		driver.DialectMssql:  "UNIQUEIDENTIFIER",
+	}}
+}
+
+// TypeJson creates a JSON ColumnType.
+func TypeJson() ColumnType {
+	return &columnType{name: "JSON", dialects: map[driver.Dialect]string{
+		driver.DialectSqlite: "TEXT",
+		driver.DialectMssql:  "NVARCHAR(MAX)",
+	}}
+}
+
+// TypeJsonb creates a binary JSON ColumnType.
+func TypeJsonb() ColumnType {
+	return &columnType{name: "JSONB", dialects: map[driver.Dialect]string{
+		driver.DialectSqlite: "TEXT",
+		driver.DialectMysql:  "JSON",
+		driver.DialectMssql:  "NVARCHAR(MAX)",
+	}}
+}
+
+// TypeArray creates an array ColumnType of the specified element type. Arrays are not supported by sqlite, mysql and mssql.
+func TypeArray(elem ColumnType) ColumnType {
+	return &arrayType{elem: elem}
+}
+
+// ColumnDef creates a new ColumnDefBuilder for the column with the specified name and data type.
+func ColumnDef(name string, typ ColumnType) ColumnDefBuilder {
+	return &columnDef{name: Column(name), typ: typ}
+}
+
+// PrimaryKey creates a PRIMARY KEY table constraint for the specified columns.
+func PrimaryKey(columns ...string) ConstraintBuilder {
+	return &constraint{constraintType: constraintPrimaryKey, columns: toColumns(columns)}
+}
+
+// Unique creates a UNIQUE table constraint for the specified columns.
+func Unique(columns ...string) ConstraintBuilder {
+	return &constraint{constraintType: constraintUnique, columns: toColumns(columns)}
+}
+
+// Check creates a CHECK table constraint with the specified expression.
+func Check(exp driver.Sqler) ConstraintBuilder {
+	return &constraint{constraintType: constraintCheck, check: exp}
+}
+
+// ForeignKey creates a FOREIGN KEY table constraint for the specified columns.
+func ForeignKey(columns ...string) ForeignKeyBuilder {
+	return &foreignKey{columns: toColumns(columns)}
+}
+
+// Sql generates the dialect-specific name of the data type.
+func (ct *columnType) Sql(options *driver.SqlOptions) (string, []any, error) {
+	if name, ok := ct.dialects[options.Dialect]; ok {
+		return name, nil, nil
+	}
+
+	return ct.name, nil, nil
+}
+
+// Sql generates the array data type, such as `TEXT[]`. Returns an error for the dialects without arrays.
+func (at *arrayType) Sql(options *driver.SqlOptions) (string, []any, error) {
+	switch options.Dialect {
+	case driver.DialectSqlite, driver.DialectMysql, driver.DialectMssql:
+		return "", nil, fmt.Errorf("array type: %w %q", ErrUnsupportedDialect, options.Dialect)
+	}
+
+	sql, args, err := at.elem.Sql(options)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return sql + "[]", args, nil
+}
+
+// NotNull adds the NOT NULL constraint and returns the updated ColumnDefBuilder.
+func (cd *columnDef) NotNull() ColumnDefBuilder {
+	cd.notNull = true
+	return cd
+}
+
+// Default sets the default value and returns the updated ColumnDefBuilder.
+func (cd *columnDef) Default(value any) ColumnDefBuilder {
+	cd.hasDefault = true
+	cd.def = value
+	return cd
+}
+
+// PrimaryKey marks the column as the primary key and returns the updated ColumnDefBuilder.
+func (cd *columnDef) PrimaryKey() ColumnDefBuilder {
+	cd.primaryKey = true
+	return cd
+}
+
+// Unique adds the UNIQUE constraint and returns the updated ColumnDefBuilder.
+func (cd *columnDef) Unique() ColumnDefBuilder {
+	cd.unique = true
+	return cd
+}
+
+// Check appends the CHECK constraint and returns the updated ColumnDefBuilder.
+func (cd *columnDef) Check(exp driver.Sqler) ColumnDefBuilder {
+	if exp != nil {
+		cd.checks = append(cd.checks, exp)
+	}
+
+	return cd
+}
+
+// References sets the referenced table and column and returns the updated ColumnDefBuilder.
+func (cd *columnDef) References(table string, column string) ColumnDefBuilder {
+	cd.reference = &reference{table: Column(table), columns: []Column{Column(column)}}
+	return cd
+}
+
+// OnDelete sets the ON DELETE action of the reference and returns the updated ColumnDefBuilder.
+// Has no effect if the column doesn't reference another table.
+func (cd *columnDef) OnDelete(action ReferenceAction) ColumnDefBuilder {
+	if cd.reference != nil {
+		cd.reference.onDelete = action
+	}
+
+	return cd
+}
+
+// OnUpdate sets the ON UPDATE action of the reference and returns the updated ColumnDefBuilder.
+// Has no effect if the column doesn't reference another table.
+func (cd *columnDef) OnUpdate(action ReferenceAction) ColumnDefBuilder {
+	if cd.reference != nil {
+		cd.reference.onUpdate = action
+	}
+
+	return cd
+}
+
+// Sql generates the column definition, such as `"id" INTEGER NOT NULL PRIMARY KEY`.
+// Literal default values are rendered as placeholders, which are inlined by the DDL statements.
+func (cd *columnDef) Sql(options *driver.SqlOptions) (string, []any, error) {
+	if cd.typ == nil {
+		return "", nil, fmt.Errorf("column %q requires a data type", cd.name)
+	}
+
+	var buf strings.Builder
+	sql, args, err := cd.name.Sql(options)
+	if err != nil {
+		return "", nil, err
+	}
+
+	buf.WriteString(sql)
+	buf.WriteByte(' ')
+	sqlType, typeArgs, err := cd.typ.Sql(options)
+	if err != nil {
+		return "", nil, err
+	}
+
+	args = append(args, typeArgs...)
+	buf.WriteString(sqlType)
+
+	if cd.notNull {
+		buf.WriteString(" NOT NULL")
+	}
+
+	if cd.hasDefault {
+		buf.WriteString(" DEFAULT ")
+		if exp, ok := cd.def.(driver.Sqler); ok {
+			sqlDef, defArgs, err := exp.Sql(options)
+			if err != nil {
+				return "", nil, err
+			}
+
+			args = append(args, defArgs...)
+			buf.WriteString("(" + sqlDef + ")")
+		} else {
+			args = append(args, cd.def)
+			buf.WriteByte(driver.Placeholder)
+		}
+	}
+
+	if cd.primaryKey {
+		buf.WriteString(" PRIMARY KEY")
+	}
+
+	if cd.unique {
+		buf.WriteString(" UNIQUE")
+	}
+
+	for i := range cd.checks {
+		sqlCheck, checkArgs, err := cd.checks[i].Sql(options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		args = append(args, checkArgs...)
+		buf.WriteString(" CHECK (" + sqlCheck + ")")
+	}
+
+	if cd.reference != nil {
+		sqlRef, refArgs, err := cd.reference.Sql(options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		args = append(args, refArgs...)
+		buf.WriteByte(' ')
+		buf.WriteString(sqlRef)
+	}
+
+	return buf.String(), args, nil
+}
+
+// Named sets the name of the constraint and returns the updated ConstraintBuilder.
+func (c *constraint) Named(name string) ConstraintBuilder {
+	c.name = Column(name)
+	return c
+}
+
+// Sql generates the table constraint, such as `CONSTRAINT "name" UNIQUE ("a","b")`.
+func (c *constraint) Sql(options *driver.SqlOptions) (string, []any, error) {
+	sql, args, err := constraintName(c.name, options)
+	if err != nil {
+		return "", nil, err
+	}
+
+	sql += c.constraintType.String()
+	if c.constraintType == constraintCheck {
+		if c.check == nil {
+			return "", nil, fmt.Errorf("CHECK constraint requires an expression")
+		}
+
+		sqlCheck, checkArgs, err := c.check.Sql(options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		args = append(args, checkArgs...)
+		return sql + " (" + sqlCheck + ")", args, nil
+	}
+
+	if len(c.columns) == 0 {
+		return "", nil, fmt.Errorf("%s constraint: %w", c.constraintType, ErrFieldsEmpty)
+	}
+
+	sqlColumns, columnsArgs, err := concatFields(c.columns, options)
+	if err != nil {
+		return "", nil, err
+	}
+
+	args = append(args, columnsArgs...)
+	return sql + " (" + sqlColumns + ")", args, nil
+}
+
+// Named sets the name of the constraint and returns the updated ForeignKeyBuilder.
+func (fk *foreignKey) Named(name string) ForeignKeyBuilder {
+	fk.name = Column(name)
+	return fk
+}
+
+// References sets the referenced table and columns of the foreign key and returns the updated ForeignKeyBuilder.
+func (fk *foreignKey) References(table string, columns ...string) ForeignKeyBuilder {
+	fk.reference.table = Column(table)
+	fk.reference.columns = toColumns(columns)
+	return fk
+}
+
+// OnDelete sets the ON DELETE action of the foreign key and returns the updated ForeignKeyBuilder.
+func (fk *foreignKey) OnDelete(action ReferenceAction) ForeignKeyBuilder {
+	fk.reference.onDelete = action
+	return fk
+}
+
+// OnUpdate sets the ON UPDATE action of the foreign key and returns the updated ForeignKeyBuilder.
+func (fk *foreignKey) OnUpdate(action ReferenceAction) ForeignKeyBuilder {
+	fk.reference.onUpdate = action
+	return fk
+}
+
+// Sql generates the foreign key constraint, such as `FOREIGN KEY ("user_id") REFERENCES "users" ("id")`.
+func (fk *foreignKey) Sql(options *driver.SqlOptions) (string, []any, error) {
+	if len(fk.columns) == 0 {
+		return "", nil, fmt.Errorf("FOREIGN KEY constraint: %w", ErrFieldsEmpty)
+	}
+
+	sql, args, err := constraintName(fk.name, options)
+	if err != nil {
+		return "", nil, err
+	}
+
+	sqlColumns, columnsArgs, err := concatFields(fk.columns, options)
+	if err != nil {
+		return "", nil, err
+	}
+
+	args = append(args, columnsArgs...)
+	sqlRef, refArgs, err := fk.reference.Sql(options)
+	if err != nil {
+		return "", nil, err
+	}
+
+	args = append(args, refArgs...)
+	return sql + "FOREIGN KEY (" + sqlColumns + ") " + sqlRef, args, nil
+}
+
+// Sql generates the REFERENCES clause with the referenced table, columns, and actions.
+func (r *reference) Sql(options *driver.SqlOptions) (string, []any, error) {
+	if r.table.IsZero() {
+		return "", nil, fmt.Errorf("FOREIGN KEY constraint requires a referenced table")
+	}
+
+	sql, args, err := r.table.Sql(options)
+	if err != nil {
+		return "", nil, err
+	}
+
+	sql = "REFERENCES " + sql
+	if len(r.columns) > 0 {
+		sqlColumns, columnsArgs, err := concatFields(r.columns, options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		args = append(args, columnsArgs...)
+		sql += " (" + sqlColumns + ")"
+	}
+
+	if r.onDelete != ReferenceNoAction {
+		sql += " ON DELETE " + r.onDelete.String()
+	}
+
+	if r.onUpdate != ReferenceNoAction {
+		sql += " ON UPDATE " + r.onUpdate.String()
+	}
+
+	return sql, args, nil
+}
+
+// String returns the SQL representation of the ReferenceAction, such as "CASCADE" or "SET NULL".
+func (r ReferenceAction) String() string {
+	switch r {
+	case ReferenceRestrict:
+		return "RESTRICT"
+	case ReferenceCascade:
+		return "CASCADE"
+	case ReferenceSetNull:
+		return "SET NULL"
+	case ReferenceSetDefault:
+		return "SET DEFAULT"
+	}
+
+	return "NO ACTION"
+}
+
+// String returns the SQL representation of the constraintType, such as "PRIMARY KEY" or "UNIQUE".
+func (c constraintType) String() string {
+	switch c {
+	case constraintUnique:
+		return "UNIQUE"
+	case constraintCheck:
+		return "CHECK"
+	}
+
+	return "PRIMARY KEY"
+}
+
+// constraintName generates the `CONSTRAINT "name" ` prefix of the table constraint if the name is set.
+func constraintName(name Column, options *driver.SqlOptions) (string, []any, error) {
+	if name.IsZero() {
+		return "", nil, nil
+	}
+
+	sql, args, err := name.Sql(options)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return "CONSTRAINT " + sql + " ", args, nil
+}
+
+// toColumns converts the list of names to the list of columns.
+func toColumns(names []string) []Column {
+	columns := make([]Column, len(names))
+	for i, name := range names {
+		columns[i] = Column(name)
+	}
+
+	return columns
+}
+
+// ddlSql generates the SQL string of the DDL statement with inlined arguments,
+// since DDL statements can't have placeholders.
+func ddlSql(
+	generate func(options *driver.SqlOptions) (string, []any, error),
+	options *driver.SqlOptions,
+) (string, []any, error) {
+	sql, args, err := generate(options)
+	if err != nil {
+		return "", nil, err
+	}
+
+	sql, err = inlineArgs(sql, args, options)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return sql, nil, nil
+}
+
+// inlineArgs replaces the placeholders of the SQL string with the literals of the corresponding arguments.
+// Placeholders followed by the characters of postgres operators are skipped the same way as by driver.Sql.
+func inlineArgs(sql string, args []any, options *driver.SqlOptions) (string, error) {
+	if len(args) == 0 {
+		return sql, nil
+	}
+
+	var buf strings.Builder
+	pos := 0
+	index := 0
+
+	for i := 0; i < len(sql); i++ {
+		if sql[i] != driver.Placeholder {
+			continue
+		}
+
+		if i+1 < len(sql) {
+			switch sql[i+1] {
+			case '#', '|', '>', '&', '?':
+				i++
+				continue
+			}
+		}
+
+		if index >= len(args) {
+			return "", fmt.Errorf("ddl: not enough arguments for placeholders")
+		}
+
+		literal, err := sqlLiteral(args[index], options)
+		if err != nil {
+			return "", err
+		}
+
+		buf.WriteString(sql[pos:i])
+		buf.WriteString(literal)
+		pos = i + 1
+		index++
+	}
+
+	if index != len(args) {
+		return "", fmt.Errorf("ddl: too many arguments for placeholders")
+	}
+
+	buf.WriteString(sql[pos:])
+	return buf.String(), nil
+}
+
+// sqlLiteral converts the value to the SQL literal of the dialect, quoting and escaping strings.
+func sqlLiteral(value any, options *driver.SqlOptions) (string, error) {
+	switch val := value.(type) {
+	case nil:
+		return "NULL", nil
+	case string:
+		if options.Dialect == driver.DialectMysql {
+			// MySQL treats the backslash as the escape character in the string literals.
+			val = strings.ReplaceAll(val, `\`, `\\`)
+		}
+
+		return "'" + strings.ReplaceAll(val, "'", "''") + "'", nil
+	case bool:
+		if options.Dialect == driver.DialectMssql {
+			// SQL Server has no boolean literals, BIT columns take 1 and 0.
+			if val {
+				return "1", nil
+			}
+
+			return "0", nil
+		}
+
+		if val {
+			return "TRUE", nil
+		}
+
+		return "FALSE", nil
+	case int:
+		return strconv.FormatInt(int64(val), 10), nil
+	case int8:
+		return strconv.FormatInt(int64(val), 10), nil
+	case int16:
+		return strconv.FormatInt(int64(val), 10), nil
+	case int32:
+		return strconv.FormatInt(int64(val), 10), nil
+	case int64:
+		return strconv.FormatInt(val, 10), nil
+	case uint:
+		return strconv.FormatUint(uint64(val), 10), nil
+	case uint8:
+		return strconv.FormatUint(uint64(val), 10), nil
+	case uint16:
+		return strconv.FormatUint(uint64(val), 10), nil
+	case uint32:
+		return strconv.FormatUint(uint64(val), 10), nil
+	case uint64:
+		return strconv.FormatUint(val, 10), nil
+	case float32:
+		return strconv.FormatFloat(float64(val), 'g', -1, 32), nil
+	case float64:
+		return strconv.FormatFloat(val, 'g', -1, 64), nil
+	case time.Time:
+		return "'" + val.Format("2006-01-02 15:04:05.999999999Z07:00") + "'", nil
+	case sqldriver.Valuer:
+		v, err := val.Value()
+		if err != nil {
+			return "", err
+		}
+
+		return sqlLiteral(v, options)
+	}
+
+	return "", fmt.Errorf("%w: %T can't be used as a literal", ErrUnsupportedType, value)
+}
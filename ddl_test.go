@@ -0,0 +1,293 @@
+package op
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xsqrty/op/driver"
+	"github.com/xsqrty/op/internal/testutil"
+)
+
+func TestColumnType(t *testing.T) {
+	t.Parallel()
+	sqlite := testutil.NewDialectOptions(driver.DialectSqlite)
+	testutil.RunCases(t, options, []testutil.TestCase{
+		{
+			Name:         "big_serial",
+			Builder:      TypeBigSerial(),
+			ExpectedSql:  `BIGSERIAL`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "big_serial_sqlite",
+			Builder:      TypeBigSerial(),
+			SqlOptions:   sqlite,
+			ExpectedSql:  `INTEGER`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "big_serial_mysql",
+			Builder:      TypeBigSerial(),
+			SqlOptions:   testutil.NewDialectOptions(driver.DialectMysql),
+			ExpectedSql:  `BIGINT AUTO_INCREMENT`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "varchar",
+			Builder:      TypeVarchar(255),
+			ExpectedSql:  `VARCHAR(255)`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "varchar_sqlite",
+			Builder:      TypeVarchar(255),
+			SqlOptions:   sqlite,
+			ExpectedSql:  `TEXT`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "numeric",
+			Builder:      TypeNumeric(10, 2),
+			ExpectedSql:  `NUMERIC(10,2)`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "numeric_sqlite",
+			Builder:      TypeNumeric(10, 2),
+			SqlOptions:   sqlite,
+			ExpectedSql:  `NUMERIC`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "timestamptz",
+			Builder:      TypeTimestampTz(),
+			ExpectedSql:  `TIMESTAMPTZ`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "timestamptz_sqlite",
+			Builder:      TypeTimestampTz(),
+			SqlOptions:   sqlite,
+			ExpectedSql:  `DATETIME`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "bytes_sqlite",
+			Builder:      TypeBytes(),
+			SqlOptions:   sqlite,
+			ExpectedSql:  `BLOB`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "raw_type",
+			Builder:      Type("CITEXT"),
+			SqlOptions:   sqlite,
+			ExpectedSql:  `CITEXT`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "array",
+			Builder:      TypeArray(TypeVarchar(10)),
+			ExpectedSql:  `VARCHAR(10)[]`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "error_array_sqlite",
+			Builder:      TypeArray(TypeText()),
+			SqlOptions:   sqlite,
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `array type: unsupported in dialect "sqlite"`,
+		},
+	})
+}
+
+func TestColumnDef(t *testing.T) {
+	t.Parallel()
+	testutil.RunCases(t, options, []testutil.TestCase{
+		{
+			Name:         "column",
+			Builder:      ColumnDef("name", TypeText()),
+			ExpectedSql:  `"name" TEXT`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "primary_key",
+			Builder:      ColumnDef("id", TypeBigSerial()).PrimaryKey(),
+			ExpectedSql:  `"id" BIGSERIAL PRIMARY KEY`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "not_null_default",
+			Builder:      ColumnDef("status", TypeText()).NotNull().Default("new"),
+			ExpectedSql:  `"status" TEXT NOT NULL DEFAULT ?`,
+			ExpectedArgs: []any{"new"},
+		},
+		{
+			Name:         "default_expression",
+			Builder:      ColumnDef("created_at", TypeTimestamp()).Default(driver.Pure("CURRENT_TIMESTAMP")),
+			ExpectedSql:  `"created_at" TIMESTAMP DEFAULT (CURRENT_TIMESTAMP)`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "unique_check",
+			Builder:      ColumnDef("age", TypeInteger()).Unique().Check(Gte("age", 0)),
+			ExpectedSql:  `"age" INTEGER UNIQUE CHECK ("age" >= ?)`,
+			ExpectedArgs: []any{0},
+		},
+		{
+			Name: "references",
+			Builder: ColumnDef("user_id", TypeBigInt()).
+				NotNull().
+				References("users", "id").
+				OnDelete(ReferenceCascade).
+				OnUpdate(ReferenceSetNull),
+			ExpectedSql:  `"user_id" BIGINT NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE ON UPDATE SET NULL`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "on_delete_without_references",
+			Builder:      ColumnDef("user_id", TypeBigInt()).OnDelete(ReferenceCascade),
+			ExpectedSql:  `"user_id" BIGINT`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "error_type",
+			Builder:      ColumnDef("id", nil),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `column "id" requires a data type`,
+		},
+		{
+			Name:         "error_name",
+			Builder:      ColumnDef("a+b", TypeText()),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `target "a+b" contains illegal character '+'`,
+		},
+	})
+}
+
+func TestConstraint(t *testing.T) {
+	t.Parallel()
+	testutil.RunCases(t, options, []testutil.TestCase{
+		{
+			Name:         "primary_key",
+			Builder:      PrimaryKey("tenant_id", "id"),
+			ExpectedSql:  `PRIMARY KEY ("tenant_id","id")`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "unique_named",
+			Builder:      Unique("tenant_id", "email").Named("users_email_key"),
+			ExpectedSql:  `CONSTRAINT "users_email_key" UNIQUE ("tenant_id","email")`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "check",
+			Builder:      Check(Lt("min_age", Column("max_age"))).Named("age_range"),
+			ExpectedSql:  `CONSTRAINT "age_range" CHECK ("min_age" < "max_age")`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name: "foreign_key",
+			Builder: ForeignKey("tenant_id", "user_id").
+				References("users", "tenant_id", "id").
+				OnDelete(ReferenceRestrict).
+				OnUpdate(ReferenceSetDefault).
+				Named("orders_user_fk"),
+			ExpectedSql:  `CONSTRAINT "orders_user_fk" FOREIGN KEY ("tenant_id","user_id") REFERENCES "users" ("tenant_id","id") ON DELETE RESTRICT ON UPDATE SET DEFAULT`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "foreign_key_without_columns",
+			Builder:      ForeignKey("user_id").References("users"),
+			ExpectedSql:  `FOREIGN KEY ("user_id") REFERENCES "users"`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "error_empty",
+			Builder:      Unique(),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  "UNIQUE constraint: fields is empty",
+		},
+		{
+			Name:         "error_check",
+			Builder:      Check(nil),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  "CHECK constraint requires an expression",
+		},
+		{
+			Name:         "error_foreign_key_empty",
+			Builder:      ForeignKey().References("users", "id"),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  "FOREIGN KEY constraint: fields is empty",
+		},
+		{
+			Name:         "error_foreign_key_table",
+			Builder:      ForeignKey("user_id"),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  "FOREIGN KEY constraint requires a referenced table",
+		},
+	})
+}
+
+func TestSqlLiteral(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name     string
+		value    any
+		expected string
+		err      string
+	}{
+		{name: "nil", value: nil, expected: "NULL"},
+		{name: "string", value: `it's\`, expected: `'it''s\'`},
+		{name: "true", value: true, expected: "TRUE"},
+		{name: "false", value: false, expected: "FALSE"},
+		{name: "int", value: -10, expected: "-10"},
+		{name: "uint8", value: uint8(10), expected: "10"},
+		{name: "float", value: 1.5, expected: "1.5"},
+		{name: "float32", value: float32(0.1), expected: "0.1"},
+		{
+			name:     "time",
+			value:    time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+			expected: "'2025-01-02 03:04:05Z'",
+		},
+		{name: "valuer", value: sql.NullInt64{Int64: 5, Valid: true}, expected: "5"},
+		{name: "valuer_null", value: sql.NullString{}, expected: "NULL"},
+		{name: "error", value: []int{1}, err: "unknown type: []int can't be used as a literal"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			literal, err := sqlLiteral(c.value, options)
+			if c.err != "" {
+				require.EqualError(t, err, c.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, c.expected, literal)
+		})
+	}
+}
+
+func TestInlineArgs(t *testing.T) {
+	t.Parallel()
+	sql, err := inlineArgs(`"data" ?| ? AND "a" = ? AND "b" ?? ?`, []any{"x", 1, nil}, options)
+	require.NoError(t, err)
+	require.Equal(t, `"data" ?| 'x' AND "a" = 1 AND "b" ?? NULL`, sql)
+
+	_, err = inlineArgs(`"a" = ?`, []any{1, 2}, options)
+	require.EqualError(t, err, "ddl: too many arguments for placeholders")
+
+	_, err = inlineArgs(`"a" = ? AND "b" = ?`, []any{1}, options)
+	require.EqualError(t, err, "ddl: not enough arguments for placeholders")
+}
@@ -9,6 +9,13 @@ import (
 
 // DeleteBuilder defines an interface to construct SQL DELETE statements with customizable conditions and options.
 type DeleteBuilder interface {
+	// Cte adds a named common table expression to the WITH clause, optionally with the list of its columns.
+	Cte(name string, query driver.Sqler, columns ...string) DeleteBuilder
+	// CteRecursive adds a named common table expression to the WITH clause and marks the clause as RECURSIVE.
+	CteRecursive(name string, query driver.Sqler, columns ...string) DeleteBuilder
+	// Using adds one or more tables to the USING clause of the DELETE statement.
+	// MySQL renders the multi-table DELETE, which names the target and joins the tables in the FROM clause.
+	Using(tables ...any) DeleteBuilder
 	// Where adds a conditional expression to the DELETE statement and returns the updated DeleteBuilder.
 	Where(exp driver.Sqler) DeleteBuilder
 	// Returning adds the specified keys to the list of columns to be returned after executing the DELETE statement.
@@ -34,8 +41,10 @@ type DeleteBuilder interface {
 
 // deleteBuilder represents a SQL DELETE statement builder with configurable table, conditions, and returning keys.
 type deleteBuilder struct {
+	ctes          ctes
 	table         Alias
 	returningKeys []Alias
+	using         []Alias
 	where         And
 	err           error
 }
@@ -60,6 +69,35 @@ func Delete(table any) DeleteBuilder {
 	return db
 }
 
+// Cte adds a common table expression with the given name, query, and optional columns to the WITH clause.
+func (db *deleteBuilder) Cte(name string, query driver.Sqler, columns ...string) DeleteBuilder {
+	db.ctes.add(name, query, columns, false)
+	return db
+}
+
+// CteRecursive adds a common table expression to the WITH clause and renders the clause as WITH RECURSIVE.
+func (db *deleteBuilder) CteRecursive(name string, query driver.Sqler, columns ...string) DeleteBuilder {
+	db.ctes.add(name, query, columns, true)
+	return db
+}
+
+// Using appends the tables to the USING clause of the DELETE statement. Tables must be strings or Alias values.
+func (db *deleteBuilder) Using(tables ...any) DeleteBuilder {
+	for i := range tables {
+		switch val := tables[i].(type) {
+		case string:
+			db.using = append(db.using, ColumnAlias(Column(val)))
+		case Alias:
+			db.using = append(db.using, val)
+		default:
+			db.err = fmt.Errorf("%w: %T must be a string or Alias", ErrUnsupportedType, tables[i])
+			return db
+		}
+	}
+
+	return db
+}
+
 // Where appends a SQL condition to the deleteBuilder's WHERE clause and returns the updated DeleteBuilder instance.
 func (db *deleteBuilder) Where(exp driver.Sqler) DeleteBuilder {
 	if exp != nil {
@@ -88,7 +126,30 @@ func (db *deleteBuilder) Sql(options *driver.SqlOptions) (string, []any, error)
 	var buf strings.Builder
 	var args []any
 
-	buf.WriteString("DELETE FROM ")
+	if len(db.ctes.items) > 0 {
+		sqlCtes, ctesArgs, err := db.ctes.Sql(options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		args = append(args, ctesArgs...)
+		buf.WriteString(sqlCtes)
+		buf.WriteByte(' ')
+	}
+
+	buf.WriteString("DELETE ")
+	// MySQL names the target of the multi-table DELETE and joins the USING tables in the FROM clause
+	if len(db.using) > 0 && options.Dialect == driver.DialectMysql {
+		sqlTarget, _, err := Column(db.table.Alias()).Sql(options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		buf.WriteString(sqlTarget)
+		buf.WriteByte(' ')
+	}
+
+	buf.WriteString("FROM ")
 	sqlTable, tableArgs, err := db.table.Sql(options)
 	if err != nil {
 		return "", nil, err
@@ -97,6 +158,40 @@ func (db *deleteBuilder) Sql(options *driver.SqlOptions) (string, []any, error)
 	args = append(args, tableArgs...)
 	buf.WriteString(sqlTable)
 
+	if len(db.returningKeys) > 0 && options.Dialect == driver.DialectMssql {
+		sqlOutput, outputArgs, err := outputSql("DELETED", db.returningKeys, options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		args = append(args, outputArgs...)
+		buf.WriteString(sqlOutput)
+	}
+
+	if len(db.using) > 0 {
+		if options.Dialect == driver.DialectSqlite {
+			return "", nil, fmt.Errorf("delete using: %w %q", ErrUnsupportedDialect, options.Dialect)
+		}
+
+		// SQL Server joins the tables of the second FROM clause
+		switch options.Dialect {
+		case driver.DialectMssql:
+			buf.WriteString(" FROM ")
+		case driver.DialectMysql:
+			buf.WriteByte(options.FieldsDelim)
+		default:
+			buf.WriteString(" USING ")
+		}
+
+		sql, usingArgs, err := concatFields(db.using, options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		args = append(args, usingArgs...)
+		buf.WriteString(sql)
+	}
+
 	if len(db.where) > 0 {
 		buf.WriteString(" WHERE ")
 		sql, whereArgs, err := db.where.Sql(options)
@@ -108,7 +203,11 @@ func (db *deleteBuilder) Sql(options *driver.SqlOptions) (string, []any, error)
 		buf.WriteString(sql)
 	}
 
-	if len(db.returningKeys) > 0 {
+	if len(db.returningKeys) > 0 && options.Dialect != driver.DialectMssql {
+		if options.Dialect == driver.DialectMysql {
+			return "", nil, fmt.Errorf("returning: %w %q", ErrUnsupportedDialect, options.Dialect)
+		}
+
 		buf.WriteString(" RETURNING ")
 		sqlRet, retArgs, err := concatFields(db.returningKeys, options)
 		if err != nil {
@@ -138,9 +237,16 @@ func (db *deleteBuilder) With() string {
 	return db.table.Alias()
 }
 
-// UsingTables returns a list of table aliases used in the current delete operation.
+// UsingTables returns a list of table aliases used in the current delete operation, including the USING tables.
 func (db *deleteBuilder) UsingTables() []string {
-	return []string{db.table.Alias()}
+	usingTables := make([]string, 0, len(db.using)+1)
+	usingTables = append(usingTables, db.table.Alias())
+
+	for i := range db.using {
+		usingTables = append(usingTables, db.using[i].Alias())
+	}
+
+	return usingTables
 }
 
 // GetReturning returns the list of Alias objects representing the RETURNING clause for the deleteBuilder instance.
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"github.com/xsqrty/op/driver"
 	"github.com/xsqrty/op/internal/testutil"
 )
 
@@ -63,6 +64,46 @@ func TestDelete(t *testing.T) {
 			ExpectedArgs: []any(nil),
 			ExpectedErr:  "unknown type: int must be a string or Alias",
 		},
+		{
+			Name: "delete_using",
+			Builder: Delete("users").
+				Using("companies", As("r", Select("id").From("roles"))).
+				Where(And{Eq("users.company_id", Column("companies.id")), Eq("users.role_id", Column("r.id"))}).
+				Returning("users.id"),
+			ExpectedSql:  `DELETE FROM "users" USING "companies",(SELECT "id" FROM "roles") AS "r" WHERE ("users"."company_id" = "companies"."id" AND "users"."role_id" = "r"."id") RETURNING "users"."id"`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name: "delete_using_mysql",
+			Builder: Delete("users").
+				Using("companies", As("r", Select("id").From("roles").Where(Eq("name", "guest")))).
+				Where(And{Eq("users.company_id", Column("companies.id")), Eq("users.role_id", Column("r.id"))}),
+			SqlOptions:   testutil.NewDialectOptions(driver.DialectMysql),
+			ExpectedSql:  `DELETE "users" FROM "users","companies",(SELECT "id" FROM "roles" WHERE "name" = ?) AS "r" WHERE ("users"."company_id" = "companies"."id" AND "users"."role_id" = "r"."id")`,
+			ExpectedArgs: []any{"guest"},
+		},
+		{
+			Name:         "error_using_type",
+			Builder:      Delete("users").Using(100),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  "unknown type: int must be a string or Alias",
+		},
+		{
+			Name:         "error_using",
+			Builder:      Delete("users").Using("a+b"),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `target "a+b" contains illegal character '+'`,
+		},
+		{
+			Name:         "error_using_sqlite",
+			Builder:      Delete("users").Using("companies"),
+			SqlOptions:   testutil.NewDialectOptions(driver.DialectSqlite),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `delete using: unsupported in dialect "sqlite"`,
+		},
 	})
 }
 
@@ -73,6 +114,7 @@ func TestDeleteUsingTables(t *testing.T) {
 
 	require.Equal(t, []string{"users"}, tables)
 	require.Equal(t, "users", item.With())
+	require.Equal(t, []string{"users", "companies"}, Delete("users").Using("companies").UsingTables())
 }
 
 func TestDeleteReturning(t *testing.T) {
@@ -0,0 +1,240 @@
+package op
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/xsqrty/op/driver"
+)
+
+// dialectExpr returns the expression rendering the operator or function with the given name and operands for the dialect.
+// The driver.DialectHook of the options takes precedence over the built-in rewrites.
+// It returns nil if the expression keeps the default rendering.
+func dialectExpr(name string, args []driver.Sqler, options *driver.SqlOptions) (driver.Sqler, error) {
+	if options.DialectHook != nil {
+		expr, err := options.DialectHook(name, args, options)
+		if err != nil || expr != nil {
+			return expr, err
+		}
+	}
+
+	switch options.Dialect {
+	case driver.DialectSqlite:
+		return sqliteExpr(name, args, options)
+	case driver.DialectMysql:
+		return mysqlExpr(name, args, options)
+	case driver.DialectMssql:
+		return mssqlExpr(name, args, options)
+	}
+
+	return nil, nil
+}
+
+// sqliteExpr rewrites postgres-specific operators and functions into sqlite equivalents.
+// Arrays are represented as JSON arrays, such as the results of ArrayAgg.
+// Returns an ErrUnsupportedDialect error if sqlite has no equivalent.
+func sqliteExpr(name string, args []driver.Sqler, options *driver.SqlOptions) (driver.Sqler, error) {
+	switch name {
+	case "@>", "<@", "?|", "?&":
+		return nil, fmt.Errorf("operator %s: %w %q", name, ErrUnsupportedDialect, options.Dialect)
+	case "ARRAY_CAT", "UNNEST":
+		return nil, fmt.Errorf("function %s: %w %q", name, ErrUnsupportedDialect, options.Dialect)
+	case "ARRAY":
+		return Func("JSON_ARRAY", sqlerArgs(args)...), nil
+	}
+
+	if len(args) == 0 {
+		return nil, nil
+	}
+
+	if name == "ARRAY_LENGTH" {
+		return Func("JSON_ARRAY_LENGTH", args[0]), nil
+	}
+
+	if len(args) != 2 {
+		return nil, nil
+	}
+
+	switch name {
+	case "ILIKE":
+		return &operator{key: Lower(args[0]), operator: "LIKE", value: Lower(args[1])}, nil
+	case "NOT ILIKE":
+		return &operator{key: Lower(args[0]), operator: "NOT LIKE", value: Lower(args[1])}, nil
+	case "#>>":
+		path, err := jsonPath(args[1])
+		if err != nil {
+			return nil, err
+		}
+
+		return Func("JSON_EXTRACT", args[0], path), nil
+	case "#>":
+		path, err := jsonPath(args[1])
+		if err != nil {
+			return nil, err
+		}
+
+		return &operator{key: args[0], operator: "->", value: path}, nil
+	}
+
+	return nil, nil
+}
+
+// mysqlExpr rewrites postgres-specific operators and functions into mysql equivalents.
+// Arrays are represented as JSON arrays, such as the results of ArrayAgg.
+// Returns an ErrUnsupportedDialect error if mysql has no equivalent.
+func mysqlExpr(name string, args []driver.Sqler, options *driver.SqlOptions) (driver.Sqler, error) {
+	switch name {
+	case "?|", "?&":
+		return nil, fmt.Errorf("operator %s: %w %q", name, ErrUnsupportedDialect, options.Dialect)
+	case "ARRAY_CAT", "UNNEST":
+		return nil, fmt.Errorf("function %s: %w %q", name, ErrUnsupportedDialect, options.Dialect)
+	case "ARRAY":
+		return Func("JSON_ARRAY", sqlerArgs(args)...), nil
+	}
+
+	if len(args) == 0 {
+		return nil, nil
+	}
+
+	if name == "ARRAY_LENGTH" {
+		return Func("JSON_LENGTH", args[0]), nil
+	}
+
+	if len(args) != 2 {
+		return nil, nil
+	}
+
+	switch name {
+	case "ILIKE":
+		return &operator{key: Lower(args[0]), operator: "LIKE", value: Lower(args[1])}, nil
+	case "NOT ILIKE":
+		return &operator{key: Lower(args[0]), operator: "NOT LIKE", value: Lower(args[1])}, nil
+	case "@>":
+		return Func("JSON_CONTAINS", args[0], args[1]), nil
+	case "<@":
+		return Func("JSON_CONTAINS", args[1], args[0]), nil
+	case "#>>", "#>":
+		path, err := jsonPath(args[1])
+		if err != nil {
+			return nil, err
+		}
+
+		if name == "#>>" {
+			return Func("JSON_UNQUOTE", Func("JSON_EXTRACT", args[0], path)), nil
+		}
+
+		return Func("JSON_EXTRACT", args[0], path), nil
+	}
+
+	return nil, nil
+}
+
+// mssqlExpr rewrites postgres-specific operators and functions into SQL Server equivalents.
+// Arrays are represented as JSON arrays, JSON documents are stored as text.
+// Returns an ErrUnsupportedDialect error if SQL Server has no equivalent.
+func mssqlExpr(name string, args []driver.Sqler, options *driver.SqlOptions) (driver.Sqler, error) {
+	switch name {
+	case "@>", "<@", "?|", "?&":
+		return nil, fmt.Errorf("operator %s: %w %q", name, ErrUnsupportedDialect, options.Dialect)
+	case "ARRAY_CAT", "UNNEST", "ARRAY_LENGTH":
+		return nil, fmt.Errorf("function %s: %w %q", name, ErrUnsupportedDialect, options.Dialect)
+	case "ARRAY":
+		return Func("JSON_ARRAY", sqlerArgs(args)...), nil
+	}
+
+	if len(args) != 2 {
+		return nil, nil
+	}
+
+	switch name {
+	case "ILIKE":
+		return &operator{key: Lower(args[0]), operator: "LIKE", value: Lower(args[1])}, nil
+	case "NOT ILIKE":
+		return &operator{key: Lower(args[0]), operator: "NOT LIKE", value: Lower(args[1])}, nil
+	case "#>>", "#>":
+		path, err := jsonPath(args[1])
+		if err != nil {
+			return nil, err
+		}
+
+		if name == "#>>" {
+			return Func("JSON_VALUE", args[0], path), nil
+		}
+
+		return Func("JSON_QUERY", args[0], path), nil
+	}
+
+	return nil, nil
+}
+
+// jsonPath converts the path elements of the array into a sqlite/mysql/mssql JSON path argument, such as `$."items"[0]`.
+// Strings are interpreted as object keys and integers as array indexes.
+func jsonPath(path driver.Sqler) (driver.Sqler, error) {
+	elements, ok := path.(array)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T must be a path of keys and indexes", ErrUnsupportedType, path)
+	}
+
+	var buf strings.Builder
+	buf.WriteByte('$')
+	for _, element := range elements {
+		switch val := element.(type) {
+		case string:
+			if strings.ContainsRune(val, '"') {
+				return nil, fmt.Errorf("json path key %q contains illegal character '\"'", val)
+			}
+
+			buf.WriteString(`."` + val + `"`)
+		case int:
+			buf.WriteString("[" + strconv.Itoa(val) + "]")
+		case int64:
+			buf.WriteString("[" + strconv.FormatInt(val, 10) + "]")
+		default:
+			return nil, fmt.Errorf("%w: %T must be a string key or an int index of json path", ErrUnsupportedType, element)
+		}
+	}
+
+	return driver.Value(buf.String()), nil
+}
+
+// colSqler converts the value to a Sqler, interpreting strings as columns. Returns an error for unsupported types.
+func colSqler(v any) (driver.Sqler, error) {
+	switch val := v.(type) {
+	case string:
+		return Column(val), nil
+	case driver.Sqler:
+		return val, nil
+	}
+
+	return nil, fmt.Errorf("%w: %T", ErrUnsupportedType, v)
+}
+
+// valSqler converts the value to a Sqler, interpreting the values that are not Sqlers as arguments.
+func valSqler(v any) driver.Sqler {
+	if sqler, ok := v.(driver.Sqler); ok {
+		return sqler
+	}
+
+	return driver.Value(v)
+}
+
+// valSqlers converts the list of values to Sqlers, interpreting the values that are not Sqlers as arguments.
+func valSqlers(values []any) []driver.Sqler {
+	sqlers := make([]driver.Sqler, len(values))
+	for i := range values {
+		sqlers[i] = valSqler(values[i])
+	}
+
+	return sqlers
+}
+
+// sqlerArgs converts the list of Sqlers to the list of function arguments.
+func sqlerArgs(sqlers []driver.Sqler) []any {
+	args := make([]any, len(sqlers))
+	for i := range sqlers {
+		args[i] = sqlers[i]
+	}
+
+	return args
+}
@@ -0,0 +1,289 @@
+package op
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xsqrty/op/driver"
+	"github.com/xsqrty/op/internal/testutil"
+)
+
+func TestDialect(t *testing.T) {
+	t.Parallel()
+	sqlite := testutil.NewDialectOptions(driver.DialectSqlite)
+	postgres := testutil.NewDialectOptions(driver.DialectPostgres)
+
+	testutil.RunCases(t, sqlite, []testutil.TestCase{
+		{
+			Name:         "ilike",
+			Builder:      ILike("name", "%alex%"),
+			ExpectedSql:  `LOWER("name") LIKE LOWER(?)`,
+			ExpectedArgs: []any{"%alex%"},
+		},
+		{
+			Name:         "not_ilike",
+			Builder:      NotILike(Column("users.name"), Column("companies.name")),
+			ExpectedSql:  `LOWER("users"."name") NOT LIKE LOWER("companies"."name")`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "ilike_postgres",
+			Builder:      ILike("name", "%alex%"),
+			ExpectedSql:  `"name" ILIKE ?`,
+			ExpectedArgs: []any{"%alex%"},
+			SqlOptions:   postgres,
+		},
+		{
+			Name:         "extract_text",
+			Builder:      Eq(ExtractText("data", "items", 0, "name"), "alex"),
+			ExpectedSql:  `JSON_EXTRACT("data",?) = ?`,
+			ExpectedArgs: []any{`$."items"[0]."name"`, "alex"},
+		},
+		{
+			Name:         "extract_object",
+			Builder:      ExtractObject(Column("users.data"), "profile"),
+			ExpectedSql:  `"users"."data" -> ?`,
+			ExpectedArgs: []any{`$."profile"`},
+		},
+		{
+			Name:         "extract_text_postgres",
+			Builder:      ExtractText("data", "profile", "name"),
+			ExpectedSql:  `"data" #>> ARRAY[?,?]`,
+			ExpectedArgs: []any{"profile", "name"},
+			SqlOptions:   postgres,
+		},
+		{
+			Name:         "array",
+			Builder:      Array(1, 2, Column("age")),
+			ExpectedSql:  `JSON_ARRAY(?,?,"age")`,
+			ExpectedArgs: []any{1, 2},
+		},
+		{
+			Name:         "array_length",
+			Builder:      Gt(ArrayLength("tags"), 2),
+			ExpectedSql:  `JSON_ARRAY_LENGTH("tags") > ?`,
+			ExpectedArgs: []any{2},
+		},
+		{
+			Name:         "array_length_postgres",
+			Builder:      ArrayLength("tags"),
+			ExpectedSql:  `ARRAY_LENGTH("tags",?)`,
+			ExpectedArgs: []any{1},
+			SqlOptions:   postgres,
+		},
+		{
+			Name:         "select",
+			Builder:      Select("id").From("users").Where(And{ILike("name", "a%"), Ne(ExtractText("data", "email"), nil)}),
+			ExpectedSql:  `SELECT "id" FROM "users" WHERE (LOWER("name") LIKE LOWER(?) AND JSON_EXTRACT("data",?) IS NOT NULL)`,
+			ExpectedArgs: []any{"a%", `$."email"`},
+		},
+		{
+			Name:         "error_contains",
+			Builder:      Lc("roles", Array("admin")),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `operator @>: unsupported in dialect "sqlite"`,
+		},
+		{
+			Name:         "error_contained_by",
+			Builder:      Rc("roles", Array("admin")),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `operator <@: unsupported in dialect "sqlite"`,
+		},
+		{
+			Name:         "error_has_prop",
+			Builder:      HasProp("data", "a", "b"),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `operator ?|: unsupported in dialect "sqlite"`,
+		},
+		{
+			Name:         "error_has_props",
+			Builder:      HasProps("data", "a", "b"),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `operator ?&: unsupported in dialect "sqlite"`,
+		},
+		{
+			Name:         "error_array_concat",
+			Builder:      ArrayConcat("a", "b"),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `function ARRAY_CAT: unsupported in dialect "sqlite"`,
+		},
+		{
+			Name:         "error_array_unnest",
+			Builder:      ArrayUnnest("tags"),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `function UNNEST: unsupported in dialect "sqlite"`,
+		},
+		{
+			Name:         "error_path_type",
+			Builder:      ExtractText("data", 1.5),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  "unknown type: float64 must be a string key or an int index of json path",
+		},
+		{
+			Name:         "error_path_key",
+			Builder:      ExtractObject("data", `a"b`),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `json path key "a\"b" contains illegal character '"'`,
+		},
+		{
+			Name:         "error_path",
+			Builder:      &operator{key: "data", operator: "#>>", value: Column("path")},
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  "unknown type: op.Column must be a path of keys and indexes",
+		},
+	})
+}
+
+func TestDialectMysql(t *testing.T) {
+	t.Parallel()
+	testutil.RunCases(t, testutil.NewDialectOptions(driver.DialectMysql), []testutil.TestCase{
+		{
+			Name:         "ilike",
+			Builder:      NotILike("name", "%alex%"),
+			ExpectedSql:  `LOWER("name") NOT LIKE LOWER(?)`,
+			ExpectedArgs: []any{"%alex%"},
+		},
+		{
+			Name:         "extract_text",
+			Builder:      Eq(ExtractText("data", "items", 0, "name"), "alex"),
+			ExpectedSql:  `JSON_UNQUOTE(JSON_EXTRACT("data",?)) = ?`,
+			ExpectedArgs: []any{`$."items"[0]."name"`, "alex"},
+		},
+		{
+			Name:         "extract_object",
+			Builder:      ExtractObject(Column("users.data"), "profile"),
+			ExpectedSql:  `JSON_EXTRACT("users"."data",?)`,
+			ExpectedArgs: []any{`$."profile"`},
+		},
+		{
+			Name:         "contains",
+			Builder:      Lc("roles", Array("admin")),
+			ExpectedSql:  `JSON_CONTAINS("roles",JSON_ARRAY(?))`,
+			ExpectedArgs: []any{"admin"},
+		},
+		{
+			Name:         "contained_by",
+			Builder:      Rc("roles", Array("admin", "user")),
+			ExpectedSql:  `JSON_CONTAINS(JSON_ARRAY(?,?),"roles")`,
+			ExpectedArgs: []any{"admin", "user"},
+		},
+		{
+			Name:         "array_length",
+			Builder:      Gt(ArrayLength("tags"), 2),
+			ExpectedSql:  `JSON_LENGTH("tags") > ?`,
+			ExpectedArgs: []any{2},
+		},
+		{
+			Name:         "error_has_prop",
+			Builder:      HasProp("data", "a"),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `operator ?|: unsupported in dialect "mysql"`,
+		},
+		{
+			Name:         "error_unnest",
+			Builder:      ArrayUnnest("tags"),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `function UNNEST: unsupported in dialect "mysql"`,
+		},
+	})
+}
+
+func TestDialectMssql(t *testing.T) {
+	t.Parallel()
+	testutil.RunCases(t, testutil.NewDialectOptions(driver.DialectMssql), []testutil.TestCase{
+		{
+			Name:         "limit_one",
+			Builder:      func() driver.Sqler { sb := Select().From("users"); sb.LimitReturningOne(); return sb }(),
+			ExpectedSql:  `SELECT TOP 1 * FROM "users"`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "extract_object",
+			Builder:      ExtractObject("data", "items"),
+			ExpectedSql:  `JSON_QUERY("data",?)`,
+			ExpectedArgs: []any{`$."items"`},
+		},
+		{
+			Name:         "ilike",
+			Builder:      ILike("name", "a%"),
+			ExpectedSql:  `LOWER("name") LIKE LOWER(?)`,
+			ExpectedArgs: []any{"a%"},
+		},
+		{
+			Name:         "type_timestamptz",
+			Builder:      TypeTimestampTz(),
+			ExpectedSql:  `DATETIMEOFFSET`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "error_on_conflict",
+			Builder:      Insert("users", Inserting{"email": "a@b.c"}).OnConflict("email", DoNothing()),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `on conflict: unsupported in dialect "mssql"`,
+		},
+		{
+			Name:         "error_lateral",
+			Builder:      Select().From("users").CrossJoinLateral(As("o", Select().From("orders"))),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `lateral join: unsupported in dialect "mssql"`,
+		},
+		{
+			Name:         "error_join_using",
+			Builder:      Select().From("users").Join("profiles", Using("user_id")),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `join using: unsupported in dialect "mssql"`,
+		},
+		{
+			Name:         "error_lock",
+			Builder:      Select().From("users").ForUpdate(),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `row locking: unsupported in dialect "mssql"`,
+		},
+		{
+			Name:         "error_array_length",
+			Builder:      ArrayLength("tags"),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `function ARRAY_LENGTH: unsupported in dialect "mssql"`,
+		},
+	})
+}
+
+func TestDialectHook(t *testing.T) {
+	t.Parallel()
+	options := testutil.NewDialectOptions(driver.DialectSqlite)
+	options.DialectHook = func(name string, args []driver.Sqler, options *driver.SqlOptions) (driver.Sqler, error) {
+		switch name {
+		case "ILIKE":
+			return &operator{key: args[0], operator: "LIKE", value: args[1]}, nil
+		case "NOW":
+			return driver.Pure("CURRENT_TIMESTAMP"), nil
+		}
+
+		return nil, nil
+	}
+
+	sql, args, err := Select("id").
+		From("users").
+		Where(And{ILike("name", "a%"), Lt("created_at", Func("NOW")), Gt(ArrayLength("tags"), 1)}).
+		Sql(options)
+
+	require.NoError(t, err)
+	require.Equal(t, `SELECT "id" FROM "users" WHERE ("name" LIKE ? AND "created_at" < CURRENT_TIMESTAMP AND JSON_ARRAY_LENGTH("tags") > ?)`, sql)
+	require.Equal(t, []any{"a%", 1}, args)
+}
@@ -8,11 +8,30 @@ const (
 	Placeholder = '?'
 )
 
+// Dialect identifies the SQL dialect the statements are generated for.
+type Dialect string
+
+const (
+	// DialectPostgres represents the PostgreSQL dialect.
+	DialectPostgres Dialect = "postgres"
+	// DialectSqlite represents the SQLite dialect.
+	DialectSqlite Dialect = "sqlite"
+	// DialectMysql represents the MySQL and MariaDB dialect.
+	DialectMysql Dialect = "mysql"
+	// DialectMssql represents the Microsoft SQL Server (T-SQL) dialect.
+	DialectMssql Dialect = "mssql"
+)
+
 // sqlOption defines a functional option for configuring a SqlOptions instance.
 type sqlOption func(options *SqlOptions)
 
+// DialectHook renders the operator or function with the given name (e.g. "ILIKE", "#>>", "ARRAY_LENGTH") and operands
+// differently for the dialect. It returns a nil Sqler to keep the default rendering of the expression.
+type DialectHook func(name string, args []Sqler, options *SqlOptions) (Sqler, error)
+
 // SqlOptions defines configuration options for customizing SQL generation behavior.
 type SqlOptions struct {
+	Dialect           Dialect
 	WrapAliasBegin    byte
 	WrapAliasEnd      byte
 	WrapColumnBegin   byte
@@ -25,6 +44,7 @@ type SqlOptions struct {
 	SafeColumns       bool
 	CastFormat        func(val string, typ string) string
 	PlaceholderFormat func(number int) string
+	DialectHook       DialectHook
 }
 
 // Sqler defines the interface for generating SQL strings with arguments.
@@ -48,6 +68,13 @@ func NewSqlOptions(option ...sqlOption) *SqlOptions {
 	return options
 }
 
+// WithDialect sets the SQL dialect used to render dialect-specific clauses.
+func WithDialect(dialect Dialect) sqlOption {
+	return func(options *SqlOptions) {
+		options.Dialect = dialect
+	}
+}
+
 // WithWrapAlias enables alias wrapping, configure the alias wrapping characters.
 func WithWrapAlias(begin byte, end byte) sqlOption {
 	return func(options *SqlOptions) {
@@ -102,6 +129,13 @@ func WithCastFormat(format func(val string, typ string) string) sqlOption {
 	}
 }
 
+// WithDialectHook sets a hook to render operators and functions differently for the dialect.
+func WithDialectHook(hook DialectHook) sqlOption {
+	return func(options *SqlOptions) {
+		options.DialectHook = hook
+	}
+}
+
 // Sql generates an SQL query string and arguments.
 func Sql(b Sqler, options *SqlOptions) (string, []any, error) {
 	sql, args, err := b.Sql(options)
@@ -52,3 +52,21 @@ func TestSqlWithPlaceholders(t *testing.T) {
 	require.Equal(t, "?? @1,@2,@3", sql)
 	require.Equal(t, []any{1, "2", 3.01}, args)
 }
+
+func TestWithDialect(t *testing.T) {
+	t.Parallel()
+	require.Equal(t, DialectSqlite, NewSqlOptions(WithDialect(DialectSqlite)).Dialect)
+	require.Equal(t, Dialect(""), NewSqlOptions().Dialect)
+}
+
+func TestWithDialectHook(t *testing.T) {
+	t.Parallel()
+	options := NewSqlOptions(WithDialectHook(func(name string, args []Sqler, options *SqlOptions) (Sqler, error) {
+		return Pure(name), nil
+	}))
+
+	expr, err := options.DialectHook("ILIKE", nil, options)
+	require.NoError(t, err)
+	require.Equal(t, Pure("ILIKE"), expr)
+	require.Nil(t, NewSqlOptions().DialectHook)
+}
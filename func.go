@@ -102,7 +102,20 @@ func CountDistinct(arg any) driver.Sqler {
 }
 
 // Sql generates a SQL representation of the fun type, combining its name, prefix, and arguments with proper formatting.
+// The function is rendered differently if the dialect of the options rewrites it (e.g. ARRAY_LENGTH in sqlite).
+// Functions with a prefix, such as DISTINCT, keep the default rendering.
 func (f fun) Sql(options *driver.SqlOptions) (string, []any, error) {
+	if f.prefix == "" {
+		expr, err := dialectExpr(f.name, valSqlers(f.args), options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		if expr != nil {
+			return expr.Sql(options)
+		}
+	}
+
 	sql, args, err := list(f.args).Sql(options)
 	if err != nil {
 		return "", nil, err
@@ -0,0 +1,76 @@
+package op
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xsqrty/op/driver"
+)
+
+// grouping represents an advanced GROUP BY element, such as ROLLUP, CUBE, GROUPING SETS, or the GROUPING() function.
+// String items are interpreted as columns, an empty name renders a parenthesized grouping set.
+type grouping struct {
+	name       string
+	items      []any
+	allowEmpty bool
+}
+
+// Rollup creates a "ROLLUP (...)" grouping element, which produces the given grouping and all its prefixes (subtotals).
+// Accepts columns (string), expressions, or GroupingSet for composite columns.
+func Rollup(columns ...any) driver.Sqler {
+	return &grouping{name: "ROLLUP", items: columns}
+}
+
+// Cube creates a "CUBE (...)" grouping element, which produces all possible combinations of the given columns.
+// Accepts columns (string), expressions, or GroupingSet for composite columns.
+func Cube(columns ...any) driver.Sqler {
+	return &grouping{name: "CUBE", items: columns}
+}
+
+// GroupingSets creates a "GROUPING SETS (...)" grouping element, which groups the rows by each of the given sets separately.
+// Accepts columns (string), expressions, GroupingSet, Rollup or Cube.
+func GroupingSets(sets ...any) driver.Sqler {
+	return &grouping{name: "GROUPING SETS", items: sets}
+}
+
+// GroupingSet creates a parenthesized list of columns used as a single set of GroupingSets, Rollup or Cube.
+// An empty set "()" groups all rows into a grand total.
+func GroupingSet(columns ...any) driver.Sqler {
+	return &grouping{items: columns, allowEmpty: true}
+}
+
+// Grouping creates a "GROUPING(...)" function call, which returns a bit mask of the arguments not included in the current grouping set.
+// Use it to distinguish subtotal rows from the rows with NULL values.
+func Grouping(columns ...any) driver.Sqler {
+	return &grouping{name: "GROUPING", items: columns}
+}
+
+// Sql generates the grouping element with the list of its columns, such as `ROLLUP ("a","b")`.
+func (g *grouping) Sql(options *driver.SqlOptions) (string, []any, error) {
+	title := g.name
+	if title == "" {
+		title = "grouping set"
+	}
+
+	if options.Dialect == driver.DialectSqlite || options.Dialect == driver.DialectMysql {
+		return "", nil, fmt.Errorf("%s: %w %q", strings.ToLower(title), ErrUnsupportedDialect, options.Dialect)
+	}
+
+	if len(g.items) == 0 && !g.allowEmpty {
+		return "", nil, fmt.Errorf("%s: %w", title, ErrFieldsEmpty)
+	}
+
+	sql, args, err := joinList(options.FieldsDelim, g.items, true, options)
+	if err != nil {
+		return "", nil, err
+	}
+
+	switch g.name {
+	case "":
+		return "(" + sql + ")", args, nil
+	case "GROUPING":
+		return g.name + "(" + sql + ")", args, nil
+	}
+
+	return g.name + " (" + sql + ")", args, nil
+}
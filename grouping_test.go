@@ -0,0 +1,89 @@
+package op
+
+import (
+	"testing"
+
+	"github.com/xsqrty/op/driver"
+	"github.com/xsqrty/op/internal/testutil"
+)
+
+func TestGrouping(t *testing.T) {
+	t.Parallel()
+	testutil.RunCases(t, options, []testutil.TestCase{
+		{
+			Name: "rollup",
+			Builder: Select("region", "city", As("total", Sum("amount"))).
+				From("sales").
+				GroupBy(Rollup("region", "city")),
+			ExpectedSql:  `SELECT "region","city",(SUM("amount")) AS "total" FROM "sales" GROUP BY ROLLUP ("region","city")`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name: "cube",
+			Builder: Select("brand", "size", As("total", Sum("amount"))).
+				From("sales").
+				GroupBy(Cube("brand", GroupingSet("size", "color"))),
+			ExpectedSql:  `SELECT "brand","size",(SUM("amount")) AS "total" FROM "sales" GROUP BY CUBE ("brand",("size","color"))`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name: "grouping_sets",
+			Builder: Select("brand", "size", As("total", Sum("amount"))).
+				From("sales").
+				GroupBy(GroupingSets(GroupingSet("brand", "size"), "brand", Rollup("size"), GroupingSet())),
+			ExpectedSql:  `SELECT "brand","size",(SUM("amount")) AS "total" FROM "sales" GROUP BY GROUPING SETS (("brand","size"),"brand",ROLLUP ("size"),())`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name: "grouping_func",
+			Builder: Select(
+				"region",
+				As("is_total", Grouping("region")),
+				As("level", Grouping("region", "city")),
+				As("total", Sum("amount")),
+			).
+				From("sales").
+				Where(Gt("amount", 0)).
+				GroupBy("country", Rollup("region", "city")).
+				Having(Eq(Grouping("region"), 0)),
+			ExpectedSql:  `SELECT "region",(GROUPING("region")) AS "is_total",(GROUPING("region","city")) AS "level",(SUM("amount")) AS "total" FROM "sales" WHERE "amount" > ? GROUP BY "country",ROLLUP ("region","city") HAVING GROUPING("region") = ?`,
+			ExpectedArgs: []any{0, 0},
+		},
+		{
+			Name:         "rollup_expression",
+			Builder:      Rollup(Lower("region"), Mul("price", driver.Value(2))),
+			ExpectedSql:  `ROLLUP (LOWER("region"),("price"*?))`,
+			ExpectedArgs: []any{2},
+		},
+		{
+			Name:         "error_empty",
+			Builder:      Select().From("sales").GroupBy(Rollup()),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  "ROLLUP: fields is empty",
+		},
+		{
+			Name:         "error_column",
+			Builder:      GroupingSets(GroupingSet("a+b")),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `target "a+b" contains illegal character '+'`,
+		},
+		{
+			Name:         "error_sqlite",
+			Builder:      Select().From("sales").GroupBy(Cube("brand")),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `cube: unsupported in dialect "sqlite"`,
+			SqlOptions:   testutil.NewDialectOptions(driver.DialectSqlite),
+		},
+		{
+			Name:         "error_sqlite_grouping",
+			Builder:      Grouping("brand"),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `grouping: unsupported in dialect "sqlite"`,
+			SqlOptions:   testutil.NewDialectOptions(driver.DialectSqlite),
+		},
+	})
+}
@@ -0,0 +1,295 @@
+package op
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xsqrty/op/driver"
+)
+
+// CreateIndexBuilder provides an interface for building SQL CREATE INDEX statements.
+type CreateIndexBuilder interface {
+	// Unique makes the index UNIQUE.
+	Unique() CreateIndexBuilder
+	// Concurrently builds the index without locking writes to the table. Ignored by sqlite and SQL Server.
+	Concurrently() CreateIndexBuilder
+	// IfNotExists adds the IF NOT EXISTS clause to the CREATE INDEX statement. Not supported by SQL Server.
+	IfNotExists() CreateIndexBuilder
+	// Using sets the index method, such as btree, hash or gin. Not supported by sqlite and SQL Server.
+	Using(method string) CreateIndexBuilder
+	// Where adds a predicate to the index, making it a partial index.
+	Where(exp driver.Sqler) CreateIndexBuilder
+	// PreparedSql generates the CREATE INDEX statement, which never has placeholders, based on the provided SqlOptions.
+	PreparedSql(options *driver.SqlOptions) (string, []any, error)
+	// Sql generates the CREATE INDEX statement with inlined literals based on the provided SqlOptions.
+	Sql(options *driver.SqlOptions) (string, []any, error)
+}
+
+// DropIndexBuilder provides an interface for building SQL DROP INDEX statements.
+type DropIndexBuilder interface {
+	// IfExists adds the IF EXISTS clause to the DROP INDEX statement.
+	IfExists() DropIndexBuilder
+	// Concurrently drops the index without locking the table. Ignored by sqlite and SQL Server.
+	Concurrently() DropIndexBuilder
+	// Cascade adds the CASCADE clause, which drops the objects depending on the index. Not supported by sqlite and SQL Server.
+	Cascade() DropIndexBuilder
+	// On sets the table of the index, which is required by SQL Server and MySQL. Ignored by postgres and sqlite.
+	On(table string) DropIndexBuilder
+	// PreparedSql generates the DROP INDEX statement, which never has placeholders, based on the provided SqlOptions.
+	PreparedSql(options *driver.SqlOptions) (string, []any, error)
+	// Sql generates the DROP INDEX statement based on the provided SqlOptions.
+	Sql(options *driver.SqlOptions) (string, []any, error)
+}
+
+// createIndexBuilder is a structure for constructing SQL CREATE INDEX statements.
+type createIndexBuilder struct {
+	name         Column
+	table        Column
+	columns      []driver.Sqler
+	unique       bool
+	concurrently bool
+	ifNotExists  bool
+	method       string
+	where        And
+	err          error
+}
+
+// dropIndexBuilder is a structure for constructing SQL DROP INDEX statements.
+type dropIndexBuilder struct {
+	name         Column
+	table        Column
+	ifExists     bool
+	concurrently bool
+	cascade      bool
+}
+
+// ensures that CreateIndexBuilder and DropIndexBuilder implement the PreparedSqler interface at compile-time.
+var (
+	_ driver.PreparedSqler = CreateIndexBuilder(nil)
+	_ driver.PreparedSqler = DropIndexBuilder(nil)
+)
+
+// CreateIndex creates a new CreateIndexBuilder for the index with the specified name on the columns of the table.
+// Columns must be strings or objects implementing Sqler, such as Order or expressions.
+func CreateIndex(name string, table string, columns ...any) CreateIndexBuilder {
+	ci := &createIndexBuilder{name: Column(name), table: Column(table)}
+	for i := range columns {
+		switch val := columns[i].(type) {
+		case string:
+			ci.columns = append(ci.columns, Column(val))
+		case driver.Sqler:
+			ci.columns = append(ci.columns, val)
+		default:
+			ci.err = fmt.Errorf("%w: %T must be a string or driver.Sqler", ErrUnsupportedType, columns[i])
+			return ci
+		}
+	}
+
+	return ci
+}
+
+// DropIndex creates a new DropIndexBuilder for the index with the specified name.
+func DropIndex(name string) DropIndexBuilder {
+	return &dropIndexBuilder{name: Column(name)}
+}
+
+// Unique makes the index UNIQUE and returns the updated CreateIndexBuilder.
+func (ci *createIndexBuilder) Unique() CreateIndexBuilder {
+	ci.unique = true
+	return ci
+}
+
+// Concurrently adds the CONCURRENTLY clause and returns the updated CreateIndexBuilder.
+func (ci *createIndexBuilder) Concurrently() CreateIndexBuilder {
+	ci.concurrently = true
+	return ci
+}
+
+// IfNotExists adds the IF NOT EXISTS clause and returns the updated CreateIndexBuilder.
+func (ci *createIndexBuilder) IfNotExists() CreateIndexBuilder {
+	ci.ifNotExists = true
+	return ci
+}
+
+// Using sets the index method and returns the updated CreateIndexBuilder.
+func (ci *createIndexBuilder) Using(method string) CreateIndexBuilder {
+	ci.method = method
+	return ci
+}
+
+// Where appends the predicate of the partial index and returns the updated CreateIndexBuilder.
+func (ci *createIndexBuilder) Where(exp driver.Sqler) CreateIndexBuilder {
+	if exp != nil {
+		ci.where = append(ci.where, exp)
+	}
+
+	return ci
+}
+
+// Sql generates the CREATE INDEX statement, such as `CREATE UNIQUE INDEX "name" ON "users" ("email") WHERE ...`.
+// The literals of the predicate are inlined since DDL statements can't have placeholders.
+func (ci *createIndexBuilder) Sql(options *driver.SqlOptions) (string, []any, error) {
+	return ddlSql(ci.sql, options)
+}
+
+// PreparedSql generates the CREATE INDEX statement. The statement has no placeholders and arguments.
+func (ci *createIndexBuilder) PreparedSql(options *driver.SqlOptions) (string, []any, error) {
+	return ci.Sql(options)
+}
+
+// sql generates the CREATE INDEX statement with placeholders for the literals.
+func (ci *createIndexBuilder) sql(options *driver.SqlOptions) (string, []any, error) {
+	if ci.err != nil {
+		return "", nil, ci.err
+	}
+
+	if len(ci.columns) == 0 {
+		return "", nil, fmt.Errorf("create index: %w", ErrFieldsEmpty)
+	}
+
+	if ci.method != "" && (options.Dialect == driver.DialectSqlite || options.Dialect == driver.DialectMssql) {
+		return "", nil, fmt.Errorf("create index using: %w %q", ErrUnsupportedDialect, options.Dialect)
+	}
+
+	if ci.ifNotExists && options.Dialect == driver.DialectMssql {
+		return "", nil, fmt.Errorf("create index if not exists: %w %q", ErrUnsupportedDialect, options.Dialect)
+	}
+
+	var buf strings.Builder
+	buf.WriteString("CREATE ")
+	if ci.unique {
+		buf.WriteString("UNIQUE ")
+	}
+
+	buf.WriteString("INDEX ")
+	if ci.concurrently && options.Dialect != driver.DialectSqlite && options.Dialect != driver.DialectMssql {
+		buf.WriteString("CONCURRENTLY ")
+	}
+
+	if ci.ifNotExists {
+		buf.WriteString("IF NOT EXISTS ")
+	}
+
+	sql, args, err := ci.name.Sql(options)
+	if err != nil {
+		return "", nil, err
+	}
+
+	buf.WriteString(sql)
+	buf.WriteString(" ON ")
+	sqlTable, tableArgs, err := ci.table.Sql(options)
+	if err != nil {
+		return "", nil, err
+	}
+
+	args = append(args, tableArgs...)
+	buf.WriteString(sqlTable)
+
+	if ci.method != "" {
+		sqlMethod, methodArgs, err := Column(ci.method).Sql(options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		args = append(args, methodArgs...)
+		buf.WriteString(" USING ")
+		buf.WriteString(sqlMethod)
+	}
+
+	sqlColumns, columnsArgs, err := concatFields(ci.columns, options)
+	if err != nil {
+		return "", nil, err
+	}
+
+	args = append(args, columnsArgs...)
+	buf.WriteString(" (")
+	buf.WriteString(sqlColumns)
+	buf.WriteByte(')')
+
+	if len(ci.where) > 0 {
+		sqlWhere, whereArgs, err := ci.where.Sql(options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		args = append(args, whereArgs...)
+		buf.WriteString(" WHERE ")
+		buf.WriteString(sqlWhere)
+	}
+
+	return buf.String(), args, nil
+}
+
+// IfExists adds the IF EXISTS clause and returns the updated DropIndexBuilder.
+func (di *dropIndexBuilder) IfExists() DropIndexBuilder {
+	di.ifExists = true
+	return di
+}
+
+// Concurrently adds the CONCURRENTLY clause and returns the updated DropIndexBuilder.
+func (di *dropIndexBuilder) Concurrently() DropIndexBuilder {
+	di.concurrently = true
+	return di
+}
+
+// Cascade adds the CASCADE clause and returns the updated DropIndexBuilder.
+func (di *dropIndexBuilder) Cascade() DropIndexBuilder {
+	di.cascade = true
+	return di
+}
+
+// On sets the table of the index and returns the updated DropIndexBuilder.
+func (di *dropIndexBuilder) On(table string) DropIndexBuilder {
+	di.table = Column(table)
+	return di
+}
+
+// Sql generates the DROP INDEX statement, such as `DROP INDEX CONCURRENTLY IF EXISTS "users_email_idx"`.
+// SQL Server and MySQL render the table of the index, such as `DROP INDEX [users_email_idx] ON [users]`.
+func (di *dropIndexBuilder) Sql(options *driver.SqlOptions) (string, []any, error) {
+	if di.cascade && (options.Dialect == driver.DialectSqlite || options.Dialect == driver.DialectMssql) {
+		return "", nil, fmt.Errorf("drop index cascade: %w %q", ErrUnsupportedDialect, options.Dialect)
+	}
+
+	if di.table == "" && options.Dialect == driver.DialectMssql {
+		return "", nil, fmt.Errorf("drop index: table of the index %q is required for dialect %q", di.name, options.Dialect)
+	}
+
+	var buf strings.Builder
+	buf.WriteString("DROP INDEX ")
+	if di.concurrently && options.Dialect != driver.DialectSqlite && options.Dialect != driver.DialectMssql {
+		buf.WriteString("CONCURRENTLY ")
+	}
+
+	if di.ifExists {
+		buf.WriteString("IF EXISTS ")
+	}
+
+	sql, args, err := di.name.Sql(options)
+	if err != nil {
+		return "", nil, err
+	}
+
+	buf.WriteString(sql)
+	if di.table != "" && (options.Dialect == driver.DialectMssql || options.Dialect == driver.DialectMysql) {
+		sqlTable, tableArgs, err := di.table.Sql(options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		args = append(args, tableArgs...)
+		buf.WriteString(" ON ")
+		buf.WriteString(sqlTable)
+	}
+
+	if di.cascade {
+		buf.WriteString(" CASCADE")
+	}
+
+	return buf.String(), args, nil
+}
+
+// PreparedSql generates the DROP INDEX statement. The statement has no placeholders and arguments.
+func (di *dropIndexBuilder) PreparedSql(options *driver.SqlOptions) (string, []any, error) {
+	return di.Sql(options)
+}
@@ -0,0 +1,131 @@
+package op
+
+import (
+	"testing"
+
+	"github.com/xsqrty/op/driver"
+	"github.com/xsqrty/op/internal/testutil"
+)
+
+func TestCreateIndex(t *testing.T) {
+	t.Parallel()
+	sqlite := testutil.NewDialectOptions(driver.DialectSqlite)
+	testutil.RunCases(t, options, []testutil.TestCase{
+		{
+			Name:         "create_index",
+			Builder:      CreateIndex("users_name_idx", "users", "name"),
+			ExpectedSql:  `CREATE INDEX "users_name_idx" ON "users" ("name")`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name: "create_index_partial",
+			Builder: CreateIndex("users_email_key", "users", Lower("email"), Desc("created_at")).
+				Unique().
+				Concurrently().
+				IfNotExists().
+				Where(Eq("deleted", false)).
+				Where(Ne("role", "guest")),
+			ExpectedSql:  `CREATE UNIQUE INDEX CONCURRENTLY IF NOT EXISTS "users_email_key" ON "users" (LOWER("email"),"created_at" DESC) WHERE ("deleted" = FALSE AND "role" != 'guest')`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name: "create_index_partial_sqlite",
+			Builder: CreateIndex("users_email_key", "users", "email").
+				Unique().
+				Concurrently().
+				Where(Eq("deleted", false)),
+			SqlOptions:   sqlite,
+			ExpectedSql:  `CREATE UNIQUE INDEX "users_email_key" ON "users" ("email") WHERE "deleted" = FALSE`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "create_index_using",
+			Builder:      CreateIndex("users_tags_idx", "users", "tags").Using("gin"),
+			ExpectedSql:  `CREATE INDEX "users_tags_idx" ON "users" USING "gin" ("tags")`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "error_empty",
+			Builder:      CreateIndex("users_idx", "users"),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  "create index: fields is empty",
+		},
+		{
+			Name:         "error_column_type",
+			Builder:      CreateIndex("users_idx", "users", 1),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  "unknown type: int must be a string or driver.Sqler",
+		},
+		{
+			Name:         "error_sqlite_using",
+			Builder:      CreateIndex("users_tags_idx", "users", "tags").Using("gin"),
+			SqlOptions:   sqlite,
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `create index using: unsupported in dialect "sqlite"`,
+		},
+		{
+			Name:         "error_column",
+			Builder:      CreateIndex("users_idx", "users", "a+b"),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `target "a+b" contains illegal character '+'`,
+		},
+	})
+}
+
+func TestDropIndex(t *testing.T) {
+	t.Parallel()
+	sqlite := testutil.NewDialectOptions(driver.DialectSqlite)
+	testutil.RunCases(t, options, []testutil.TestCase{
+		{
+			Name:         "drop_index",
+			Builder:      DropIndex("users_name_idx").Concurrently().IfExists(),
+			ExpectedSql:  `DROP INDEX CONCURRENTLY IF EXISTS "users_name_idx"`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "drop_index_cascade",
+			Builder:      DropIndex("users_name_idx").Cascade(),
+			ExpectedSql:  `DROP INDEX "users_name_idx" CASCADE`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "drop_index_sqlite",
+			Builder:      DropIndex("users_name_idx").Concurrently().IfExists(),
+			SqlOptions:   sqlite,
+			ExpectedSql:  `DROP INDEX IF EXISTS "users_name_idx"`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "drop_index_on",
+			Builder:      DropIndex("users_name_idx").On("users"),
+			ExpectedSql:  `DROP INDEX "users_name_idx"`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "drop_index_on_mysql",
+			Builder:      DropIndex("users_name_idx").On("users"),
+			SqlOptions:   testutil.NewDialectOptions(driver.DialectMysql),
+			ExpectedSql:  `DROP INDEX "users_name_idx" ON "users"`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "error_sqlite_cascade",
+			Builder:      DropIndex("users_name_idx").Cascade(),
+			SqlOptions:   sqlite,
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `drop index cascade: unsupported in dialect "sqlite"`,
+		},
+		{
+			Name:         "error_name",
+			Builder:      DropIndex("a+b"),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `target "a+b" contains illegal character '+'`,
+		},
+	})
+}
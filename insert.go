@@ -10,10 +10,18 @@ import (
 
 // InsertBuilder defines an interface for constructing SQL insert queries with support for columns, values, conflict resolution, and returning.
 type InsertBuilder interface {
+	// Cte adds a named common table expression to the WITH clause, optionally with the list of its columns.
+	Cte(name string, query driver.Sqler, columns ...string) InsertBuilder
+	// CteRecursive adds a named common table expression to the WITH clause and marks the clause as RECURSIVE.
+	CteRecursive(name string, query driver.Sqler, columns ...string) InsertBuilder
 	// Columns sets the column names for the insert query and returns the InsertBuilder for further chaining.
 	Columns(columns ...string) InsertBuilder
 	// Values adds a set of values for an SQL insert query, corresponding to the columns specified earlier.
 	Values(values ...any) InsertBuilder
+	// FromSelect sets the SELECT query whose result rows are inserted instead of the values.
+	FromSelect(query SelectBuilder) InsertBuilder
+	// DefaultValues sets the insert query to insert a single row filled with the default values of the columns.
+	DefaultValues() InsertBuilder
 	// OnConflict adds conflict resolution behavior to the insert operation using a target and a specified action.
 	OnConflict(target any, do driver.Sqler) InsertBuilder
 	// Returning specifies the columns or expressions to be returned after an INSERT operation and returns the updated InsertBuilder.
@@ -41,18 +49,22 @@ type Inserting map[string]any
 
 // insertBuilder is a type for constructing SQL INSERT statements.
 type insertBuilder struct {
+	ctes          ctes
 	into          Alias
 	many          bool
 	onConflict    *conflict
 	returningKeys []Alias
 	insertingKeys []Column
 	insertingVals [][]any
+	query         SelectBuilder
+	defaultValues bool
 	err           error
 }
 
 var (
 	ErrNoInsertValues = errors.New("no insert values")
 	ErrForInsertMany  = errors.New("Values/Columns available only for InsertMany")
+	ErrInsertSource   = errors.New("insert source must be either values, select query or default values")
 )
 
 // is a compile-time assertion ensuring InsertBuilder implements the Returnable interface.
@@ -75,6 +87,18 @@ func Insert(into any, inserting Inserting) InsertBuilder {
 	return ib
 }
 
+// Cte adds a common table expression with the given name, query, and optional columns to the WITH clause.
+func (ib *insertBuilder) Cte(name string, query driver.Sqler, columns ...string) InsertBuilder {
+	ib.ctes.add(name, query, columns, false)
+	return ib
+}
+
+// CteRecursive adds a common table expression to the WITH clause and renders the clause as WITH RECURSIVE.
+func (ib *insertBuilder) CteRecursive(name string, query driver.Sqler, columns ...string) InsertBuilder {
+	ib.ctes.add(name, query, columns, true)
+	return ib
+}
+
 // Columns specify the column names for the insert operation.
 // If the operation is not an InsertMany, sets an error in the builder.
 func (ib *insertBuilder) Columns(columns ...string) InsertBuilder {
@@ -102,17 +126,52 @@ func (ib *insertBuilder) Values(values ...any) InsertBuilder {
 	return ib
 }
 
+// FromSelect sets the SELECT query as the source of the inserted rows and returns the InsertBuilder.
+// Sets an error if not in InsertMany mode.
+func (ib *insertBuilder) FromSelect(query SelectBuilder) InsertBuilder {
+	if !ib.many {
+		ib.err = ErrForInsertMany
+		return ib
+	}
+
+	ib.query = query
+	return ib
+}
+
+// DefaultValues sets the DEFAULT VALUES mode of the insert query and returns the InsertBuilder.
+// Sets an error if not in InsertMany mode.
+func (ib *insertBuilder) DefaultValues() InsertBuilder {
+	if !ib.many {
+		ib.err = ErrForInsertMany
+		return ib
+	}
+
+	ib.defaultValues = true
+	return ib
+}
+
 // OnConflict configures the "ON CONFLICT" clause for the insert query, specifying the target and action to perform.
-// The target must be a string or an Alias, while the action is defined by a driver.Sqler implementation.
+// The target must be a string, an Alias, a slice of strings, a ConflictTarget, or nil to omit the conflict target,
+// while the action is defined by a driver.Sqler implementation.
 func (ib *insertBuilder) OnConflict(target any, do driver.Sqler) InsertBuilder {
 	conf := &conflict{expr: do}
 	switch val := target.(type) {
 	case string:
-		conf.target = ColumnAlias(Column(val))
+		conf.target = ConflictColumns(val)
 	case Alias:
+		conf.target = ConflictColumns(val)
+	case []string:
+		columns := make([]any, len(val))
+		for i := range val {
+			columns[i] = val[i]
+		}
+
+		conf.target = ConflictColumns(columns...)
+	case ConflictTarget:
 		conf.target = val
+	case nil:
 	default:
-		ib.err = fmt.Errorf("%w: %T must be a string or Alias", ErrUnsupportedType, target)
+		ib.err = fmt.Errorf("%w: %T must be a string, Alias or ConflictTarget", ErrUnsupportedType, target)
 		return ib
 	}
 
@@ -136,18 +195,32 @@ func (ib *insertBuilder) Sql(options *driver.SqlOptions) (string, []any, error)
 		return "", nil, ib.err
 	}
 
-	if len(ib.insertingKeys) == 0 {
-		return "", nil, fmt.Errorf("insert: %w", ErrFieldsEmpty)
-	}
-
-	if len(ib.insertingVals) == 0 {
-		return "", nil, fmt.Errorf("insert: %w", ErrNoInsertValues)
+	if err := ib.validateSource(); err != nil {
+		return "", nil, err
 	}
 
 	var buf strings.Builder
 	var args []any
 
-	buf.WriteString("INSERT INTO ")
+	if len(ib.ctes.items) > 0 {
+		sqlCtes, ctesArgs, err := ib.ctes.Sql(options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		args = append(args, ctesArgs...)
+		buf.WriteString(sqlCtes)
+		buf.WriteByte(' ')
+	}
+
+	buf.WriteString("INSERT ")
+	if options.Dialect == driver.DialectMysql && ib.onConflict != nil {
+		if _, ok := ib.onConflict.expr.(doNothing); ok {
+			buf.WriteString("IGNORE ")
+		}
+	}
+
+	buf.WriteString("INTO ")
 	sqlInto, intoArgs, err := ib.into.Sql(options)
 	if err != nil {
 		return "", nil, err
@@ -155,17 +228,56 @@ func (ib *insertBuilder) Sql(options *driver.SqlOptions) (string, []any, error)
 
 	args = append(args, intoArgs...)
 	buf.WriteString(sqlInto)
-	buf.WriteString(" (")
-	sqlKeys, keysArgs, err := concatFields(ib.insertingKeys, options)
-	if err != nil {
-		return "", nil, err
+	if len(ib.insertingKeys) > 0 {
+		buf.WriteString(" (")
+		sqlKeys, keysArgs, err := concatFields(ib.insertingKeys, options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		args = append(args, keysArgs...)
+		buf.WriteString(sqlKeys)
+		buf.WriteString(")")
+	}
+
+	if len(ib.returningKeys) > 0 && options.Dialect == driver.DialectMssql {
+		sqlOutput, outputArgs, err := outputSql("INSERTED", ib.returningKeys, options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		args = append(args, outputArgs...)
+		buf.WriteString(sqlOutput)
 	}
 
-	args = append(args, keysArgs...)
-	buf.WriteString(sqlKeys)
+	switch {
+	case ib.defaultValues:
+		if ib.onConflict != nil && options.Dialect == driver.DialectSqlite {
+			return "", nil, fmt.Errorf(
+				"insert default values on conflict: %w %q",
+				ErrUnsupportedDialect,
+				options.Dialect,
+			)
+		}
+
+		buf.WriteString(" DEFAULT VALUES")
+	case ib.query != nil:
+		sqlQuery, queryArgs, err := ib.query.Sql(options)
+		if err != nil {
+			return "", nil, err
+		}
 
-	buf.WriteString(")")
-	if len(ib.insertingVals) > 0 {
+		args = append(args, queryArgs...)
+		if ib.onConflict != nil && options.Dialect == driver.DialectSqlite {
+			// sqlite parses ON CONFLICT after the SELECT without WHERE as a join constraint
+			buf.WriteString(" SELECT * FROM (")
+			buf.WriteString(sqlQuery)
+			buf.WriteString(") WHERE true")
+		} else {
+			buf.WriteByte(' ')
+			buf.WriteString(sqlQuery)
+		}
+	default:
 		buf.WriteString(" VALUES ")
 		for i := range ib.insertingVals {
 			buf.WriteByte('(')
@@ -185,25 +297,23 @@ func (ib *insertBuilder) Sql(options *driver.SqlOptions) (string, []any, error)
 	}
 
 	if ib.onConflict != nil {
-		buf.WriteString(" ON CONFLICT (")
-		sqlTar, tarArgs, err := ib.onConflict.target.Sql(options)
+		sqlConflict, conflictArgs, err := ib.onConflict.Sql(options)
 		if err != nil {
 			return "", nil, err
 		}
 
-		args = append(args, tarArgs...)
-		buf.WriteString(sqlTar)
-		buf.WriteString(") DO ")
-		sqlExp, expArgs, err := ib.onConflict.expr.Sql(options)
-		if err != nil {
-			return "", nil, err
+		if sqlConflict != "" {
+			args = append(args, conflictArgs...)
+			buf.WriteByte(' ')
+			buf.WriteString(sqlConflict)
 		}
-
-		args = append(args, expArgs...)
-		buf.WriteString(sqlExp)
 	}
 
-	if len(ib.returningKeys) > 0 {
+	if len(ib.returningKeys) > 0 && options.Dialect != driver.DialectMssql {
+		if options.Dialect == driver.DialectMysql {
+			return "", nil, fmt.Errorf("returning: %w %q", ErrUnsupportedDialect, options.Dialect)
+		}
+
 		buf.WriteString(" RETURNING ")
 		sqlRet, retArgs, err := concatFields(ib.returningKeys, options)
 		if err != nil {
@@ -270,6 +380,35 @@ func (ib *insertBuilder) setReturning(keys []any) error {
 	return nil
 }
 
+// validateSource checks that exactly one source of the inserted rows is specified: values, select query or default values.
+func (ib *insertBuilder) validateSource() error {
+	if ib.defaultValues {
+		if len(ib.insertingKeys) > 0 || len(ib.insertingVals) > 0 || ib.query != nil {
+			return fmt.Errorf("insert: %w", ErrInsertSource)
+		}
+
+		return nil
+	}
+
+	if ib.query != nil {
+		if len(ib.insertingVals) > 0 {
+			return fmt.Errorf("insert: %w", ErrInsertSource)
+		}
+
+		return nil
+	}
+
+	if len(ib.insertingKeys) == 0 {
+		return fmt.Errorf("insert: %w", ErrFieldsEmpty)
+	}
+
+	if len(ib.insertingVals) == 0 {
+		return fmt.Errorf("insert: %w", ErrNoInsertValues)
+	}
+
+	return nil
+}
+
 // setInserting initializes insertingKeys and insertingVals with keys and values from the given Inserting map.
 func (ib *insertBuilder) setInserting(inserting Inserting) {
 	ib.insertingKeys = nil
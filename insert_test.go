@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"github.com/xsqrty/op/driver"
 	"github.com/xsqrty/op/internal/testutil"
 )
 
@@ -70,6 +71,60 @@ func TestInsert(t *testing.T) {
 			ExpectedSql:  `INSERT INTO "users" ("age","name") VALUES (?,?) ON CONFLICT ("id") DO NOTHING RETURNING "id"`,
 			ExpectedArgs: []any{10, "Alex"},
 		},
+		{
+			Name: "insert_select",
+			Builder: InsertMany("archive").
+				Columns("id", "name").
+				FromSelect(Select("id", "name").From("jobs").Where(Eq("status", "done"))),
+			ExpectedSql:  `INSERT INTO "archive" ("id","name") SELECT "id","name" FROM "jobs" WHERE "status" = ?`,
+			ExpectedArgs: []any{"done"},
+		},
+		{
+			Name:         "insert_select_without_columns",
+			Builder:      InsertMany("archive").FromSelect(Select().From("jobs")),
+			ExpectedSql:  `INSERT INTO "archive" SELECT * FROM "jobs"`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name: "insert_select_conflict_returning",
+			Builder: InsertMany("archive").
+				Columns("id", "name").
+				FromSelect(Select("id", "name").From("jobs")).
+				OnConflict("id", DoUpdate(Updates{"name": Excluded("name")})).
+				Returning("id"),
+			ExpectedSql:  `INSERT INTO "archive" ("id","name") SELECT "id","name" FROM "jobs" ON CONFLICT ("id") DO UPDATE SET "name"=EXCLUDED."name" RETURNING "id"`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name: "insert_select_conflict_sqlite",
+			Builder: InsertMany("archive").
+				Columns("id", "name").
+				FromSelect(Select("id", "name").From("jobs")).
+				OnConflict("id", DoNothing()),
+			SqlOptions:   testutil.NewDialectOptions(driver.DialectSqlite),
+			ExpectedSql:  `INSERT INTO "archive" ("id","name") SELECT * FROM (SELECT "id","name" FROM "jobs") WHERE true ON CONFLICT ("id") DO NOTHING`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "insert_default_values",
+			Builder:      InsertMany("users").DefaultValues().Returning("id"),
+			ExpectedSql:  `INSERT INTO "users" DEFAULT VALUES RETURNING "id"`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "insert_default_values_conflict",
+			Builder:      InsertMany("users").DefaultValues().OnConflict("id", DoNothing()),
+			ExpectedSql:  `INSERT INTO "users" DEFAULT VALUES ON CONFLICT ("id") DO NOTHING`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "insert_default_values_conflict_sqlite",
+			Builder:      InsertMany("users").DefaultValues().OnConflict("id", DoNothing()),
+			SqlOptions:   testutil.NewDialectOptions(driver.DialectSqlite),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `insert default values on conflict: unsupported in dialect "sqlite"`,
+		},
 		{
 			Name:         "insert_error_1",
 			Builder:      InsertMany("users"),
@@ -161,7 +216,7 @@ func TestInsert(t *testing.T) {
 				OnConflict(100, DoNothing()),
 			ExpectedSql:  "",
 			ExpectedArgs: []any(nil),
-			ExpectedErr:  "unknown type: int must be a string or Alias",
+			ExpectedErr:  "unknown type: int must be a string, Alias or ConflictTarget",
 		},
 		{
 			Name:         "insert_error_12",
@@ -177,6 +232,41 @@ func TestInsert(t *testing.T) {
 			ExpectedArgs: []any(nil),
 			ExpectedErr:  "Values/Columns available only for InsertMany",
 		},
+		{
+			Name:         "insert_error_14",
+			Builder:      Insert("users", Inserting{"a": 10}).FromSelect(Select().From("jobs")),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  "Values/Columns available only for InsertMany",
+		},
+		{
+			Name:         "insert_error_15",
+			Builder:      Insert("users", Inserting{"a": 10}).DefaultValues(),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  "Values/Columns available only for InsertMany",
+		},
+		{
+			Name:         "insert_error_16",
+			Builder:      InsertMany("users").Columns("a").DefaultValues(),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  "insert: insert source must be either values, select query or default values",
+		},
+		{
+			Name:         "insert_error_17",
+			Builder:      InsertMany("users").Columns("a").Values(1).FromSelect(Select("a").From("jobs")),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  "insert: insert source must be either values, select query or default values",
+		},
+		{
+			Name:         "insert_error_18",
+			Builder:      InsertMany("users").Columns("a").FromSelect(Select("a+b").From("jobs")),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `target "a+b" contains illegal character '+'`,
+		},
 	})
 }
 
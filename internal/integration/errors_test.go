@@ -0,0 +1,63 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/stretchr/testify/require"
+	"github.com/xsqrty/op"
+	"github.com/xsqrty/op/db"
+	"github.com/xsqrty/op/driver"
+	"github.com/xsqrty/op/orm"
+)
+
+func TestErrors_Classified(t *testing.T) {
+	t.Parallel()
+	EachConn(t, func(conn db.ConnPool) {
+		require.Equal(t, errRollback, Transact(t, ctx, conn, func(ctx context.Context) error {
+			name := gofakeit.UUID()
+			require.NoError(t, orm.Put(countriesTable, &MockCountry{Name: name}).With(ctx, conn))
+
+			err := conn.Transact(ctx, func(ctx context.Context) error {
+				return orm.Put(countriesTable, &MockCountry{Name: name}).With(ctx, conn)
+			})
+			require.ErrorIs(t, err, db.ErrUniqueViolation)
+
+			var classified *db.Error
+			require.ErrorAs(t, err, &classified)
+			require.Equal(t, countriesTable, classified.Table)
+
+			var id int
+			sql, args, err := driver.Sql(
+				op.Select("id").From(countriesTable).Where(op.Eq("name", gofakeit.UUID())),
+				conn.SqlOptions(),
+			)
+			require.NoError(t, err)
+			require.ErrorIs(t, conn.QueryRow(ctx, sql, args...).Scan(&id), db.ErrNoRows)
+
+			query := orm.Query[MockCountry](op.Select().From(countriesTable).Where(op.Eq("name", gofakeit.UUID())))
+			country, err := query.GetOne(ctx, conn)
+			require.Nil(t, country)
+			require.ErrorIs(t, err, orm.ErrNotFound)
+			require.ErrorIs(t, err, db.ErrNoRows)
+
+			country, err = query.GetOneOrNil(ctx, conn)
+			require.NoError(t, err)
+			require.Nil(t, country)
+
+			country, err = orm.Query[MockCountry](op.Select().From(countriesTable).Where(op.Eq("name", name))).
+				GetOneOrNil(ctx, conn)
+			require.NoError(t, err)
+			require.Equal(t, name, country.Name)
+
+			err = conn.Transact(ctx, func(ctx context.Context) error {
+				_, err := orm.Exec(op.Insert(countriesTable, op.Inserting{"name": nil})).With(ctx, conn)
+				return err
+			})
+			require.ErrorIs(t, err, db.ErrNotNullViolation)
+
+			return errRollback
+		}))
+	})
+}
@@ -25,6 +25,7 @@ import (
 
 const (
 	sqliteDSN      = "storage.db"
+	sqlitePureDSN  = "storage_pure.db"
 	postgresUser   = "postgres"
 	postgresPass   = "postgres"
 	postgresDB     = "integration_test"
@@ -79,8 +80,9 @@ type MockSeeding struct {
 }
 
 var (
-	pgConn     db.ConnPool
-	sqliteConn db.ConnPool
+	pgConn         db.ConnPool
+	sqliteConn     db.ConnPool
+	sqlitePureConn db.ConnPool
 )
 
 var (
@@ -120,6 +122,20 @@ func TestMain(m *testing.M) {
 		log.Panicf("failed to create sqlite tables: %v", err)
 	}
 
+	sqlitePureConn, err = connectSqlite(sqlitePureDSN, sqlite.WithPureGo())
+	if err != nil {
+		log.Panicf("failed to connect to pure-Go sqlite: %v", err)
+	}
+	defer func() {
+		os.Remove(sqlitePureDSN) // nolint: errcheck, gosec
+	}()
+	defer sqlitePureConn.Close() // nolint: errcheck, gosec
+
+	err = createSqliteTables(ctx, sqlitePureConn)
+	if err != nil {
+		log.Panicf("failed to create pure-Go sqlite tables: %v", err)
+	}
+
 	m.Run()
 }
 
@@ -183,6 +199,7 @@ func EachConn(t *testing.T, handler func(conn db.ConnPool)) {
 	t.Helper()
 	handler(pgConn)
 	handler(sqliteConn)
+	handler(sqlitePureConn)
 }
 
 func createPostgresTables(ctx context.Context, pool db.ConnPool) error {
@@ -330,14 +347,16 @@ func connectPostgres(ctx context.Context, dsn string) (db.ConnPool, error) {
 	return pool, nil
 }
 
-func connectSqlite(dsn string) (db.ConnPool, error) {
+func connectSqlite(dsn string, options ...sqlite.OpenOption) (db.ConnPool, error) {
 	os.Remove(dsn) // nolint: errcheck, gosec
 	pool, err := sqlite.Open(
 		dsn,
-		sqlite.WithConnMaxIdleTime(5*time.Minute),
-		sqlite.WithConnMaxLifetime(5*time.Minute),
-		sqlite.WithMaxIdleConns(2),
-		sqlite.WithMaxOpenConns(10),
+		append([]sqlite.OpenOption{
+			sqlite.WithConnMaxIdleTime(5 * time.Minute),
+			sqlite.WithConnMaxLifetime(5 * time.Minute),
+			sqlite.WithMaxIdleConns(2),
+			sqlite.WithMaxOpenConns(10),
+		}, options...)...,
 	)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,62 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xsqrty/op"
+	"github.com/xsqrty/op/db"
+	"github.com/xsqrty/op/migrate"
+	"github.com/xsqrty/op/orm"
+)
+
+func TestMigrate(t *testing.T) {
+	t.Parallel()
+	EachConn(t, func(conn db.ConnPool) {
+		migrations := []migrate.Migration{
+			{
+				Version: 1,
+				Name:    "create_migrated",
+				Up: func(ctx context.Context, exec db.QueryExec) error {
+					_, err := orm.Exec(op.CreateTable("Migrated").Columns(
+						op.ColumnDef("id", op.TypeBigSerial()).PrimaryKey(),
+						op.ColumnDef("name", op.TypeText()).NotNull().Default("none"),
+					)).With(ctx, exec)
+
+					return err
+				},
+				Down: func(ctx context.Context, exec db.QueryExec) error {
+					_, err := orm.Exec(op.DropTable("Migrated")).With(ctx, exec)
+					return err
+				},
+			},
+			{
+				Version: 2,
+				Name:    "index_migrated",
+				Up:      migrate.SqlStep(`CREATE INDEX "MigratedName" ON "Migrated" ("name"); INSERT INTO "Migrated" ("id") VALUES (1)`),
+				Down:    migrate.SqlStep(`DROP INDEX "MigratedName"`),
+			},
+		}
+
+		m, err := migrate.New(conn, migrations, migrate.WithTable("MigratedVersions"))
+		require.NoError(t, err)
+
+		require.NoError(t, m.Up(ctx))
+		statuses, err := m.Status(ctx)
+		require.NoError(t, err)
+		require.Len(t, statuses, 2)
+		require.True(t, statuses[0].Applied)
+		require.True(t, statuses[1].Applied)
+
+		count, err := orm.Count(op.Select().From("Migrated").Where(op.Eq("name", "none"))).With(ctx, conn)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), count)
+
+		require.NoError(t, m.DownTo(ctx, 0))
+		statuses, err = m.Status(ctx)
+		require.NoError(t, err)
+		require.False(t, statuses[0].Applied)
+		require.False(t, statuses[1].Applied)
+	})
+}
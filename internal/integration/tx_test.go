@@ -2,10 +2,12 @@ package integration
 
 import (
 	"context"
+	"database/sql"
 	"strings"
 	"testing"
 
 	"github.com/brianvoe/gofakeit/v7"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/stretchr/testify/require"
 	"github.com/xsqrty/op"
 	"github.com/xsqrty/op/db"
@@ -67,3 +69,111 @@ func TestTransact_Rollback(t *testing.T) {
 		require.Equal(t, int64(0), count)
 	})
 }
+
+func TestTransact_Options(t *testing.T) {
+	t.Parallel()
+	EachConn(t, func(conn db.ConnPool) {
+		require.NoError(t, conn.Transact(ctx, func(ctx context.Context) error {
+			_, err := orm.Count(op.Select().From(countriesTable)).With(ctx, conn)
+			return err
+		}, db.WithIsolation(sql.LevelSerializable), db.WithReadOnly(), db.WithDeferrable()))
+	})
+}
+
+func TestTransact_PostgresOptions(t *testing.T) {
+	t.Parallel()
+	var isolation string
+	require.NoError(t, pgConn.Transact(ctx, func(ctx context.Context) error {
+		return pgConn.QueryRow(ctx, "SHOW transaction_isolation").Scan(&isolation)
+	}, db.WithIsolation(sql.LevelRepeatableRead)))
+	require.Equal(t, "repeatable read", isolation)
+
+	err := pgConn.Transact(ctx, func(ctx context.Context) error {
+		return orm.Put(countriesTable, &MockCountry{
+			Name: gofakeit.UUID(),
+		}).With(ctx, pgConn)
+	}, db.WithReadOnly())
+	require.ErrorContains(t, err, "read-only transaction")
+}
+
+func TestTransact_Savepoint(t *testing.T) {
+	t.Parallel()
+	EachConn(t, func(conn db.ConnPool) {
+		outer, inner := gofakeit.UUID(), gofakeit.UUID()
+		require.NoError(t, conn.Transact(ctx, func(ctx context.Context) error {
+			err := orm.Put(countriesTable, &MockCountry{Name: outer}).With(ctx, conn)
+			if err != nil {
+				return err
+			}
+
+			err = conn.Transact(ctx, func(ctx context.Context) error {
+				err := orm.Put(countriesTable, &MockCountry{Name: inner}).With(ctx, conn)
+				if err != nil {
+					return err
+				}
+
+				return errRollback
+			})
+			require.Equal(t, errRollback, err)
+
+			return nil
+		}))
+
+		count, err := orm.Count(op.Select().From(countriesTable).Where(op.In("name", outer, inner))).
+			With(ctx, conn)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), count)
+	})
+}
+
+func TestTransact_PostgresRetry(t *testing.T) {
+	t.Parallel()
+	var attempts []int
+	err := pgConn.Transact(ctx, func(ctx context.Context) error {
+		if len(attempts) == 0 {
+			return &pgconn.PgError{Code: "40001"}
+		}
+
+		return nil
+	}, db.WithIsolation(sql.LevelSerializable), db.WithRetry(db.RetryPolicy{
+		MaxAttempts: 3,
+		OnAttempt: func(ctx context.Context, attempt int, err error) {
+			attempts = append(attempts, attempt)
+		},
+	}))
+
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2}, attempts)
+}
+
+func TestTransact_Hooks(t *testing.T) {
+	t.Parallel()
+	EachConn(t, func(conn db.ConnPool) {
+		for _, handlerErr := range []error{nil, errRollback} {
+			var calls []string
+			err := conn.Transact(ctx, func(ctx context.Context) error {
+				db.AfterRollback(ctx, func(ctx context.Context) {
+					calls = append(calls, "rollback")
+				})
+
+				require.NoError(t, conn.Transact(ctx, func(ctx context.Context) error {
+					db.AfterCommit(ctx, func(ctx context.Context) {
+						calls = append(calls, "nested_commit")
+					})
+
+					return nil
+				}))
+
+				require.Empty(t, calls)
+				return handlerErr
+			})
+
+			require.Equal(t, handlerErr, err)
+			if handlerErr == nil {
+				require.Equal(t, []string{"nested_commit"}, calls)
+			} else {
+				require.Equal(t, []string{"rollback"}, calls)
+			}
+		}
+	})
+}
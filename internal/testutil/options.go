@@ -17,3 +17,11 @@ func NewDefaultOptions() *driver.SqlOptions {
 		}),
 	)
 }
+
+// NewDialectOptions returns the default configuration of SqlOptions for the specified dialect.
+func NewDialectOptions(dialect driver.Dialect) *driver.SqlOptions {
+	options := NewDefaultOptions()
+	options.Dialect = dialect
+
+	return options
+}
@@ -0,0 +1,117 @@
+package op
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xsqrty/op/driver"
+)
+
+type (
+	// lockStrength defines the strength of the row locking clause, such as FOR UPDATE or FOR SHARE.
+	lockStrength int
+	// lockWaitType defines how the row locking clause behaves when the rows are already locked.
+	lockWaitType int
+)
+
+// lock represents a row locking clause with its strength, locked tables, and waiting behavior.
+type lock struct {
+	strength lockStrength
+	of       []Column
+	wait     lockWaitType
+}
+
+// lockUpdate represents the FOR UPDATE locking clause.
+// lockNoKeyUpdate represents the FOR NO KEY UPDATE locking clause.
+// lockShare represents the FOR SHARE locking clause.
+// lockKeyShare represents the FOR KEY SHARE locking clause.
+const (
+	lockUpdate lockStrength = iota
+	lockNoKeyUpdate
+	lockShare
+	lockKeyShare
+)
+
+// lockWaitDefault represents waiting for the locked rows to be released.
+// lockNoWait represents reporting an error instead of waiting for the locked rows.
+// lockSkipLocked represents skipping the rows that cannot be locked immediately.
+const (
+	lockWaitDefault lockWaitType = iota
+	lockNoWait
+	lockSkipLocked
+)
+
+// newLock creates a row locking clause with the given strength for the optional list of tables.
+func newLock(strength lockStrength, of []string) lock {
+	l := lock{strength: strength}
+	if len(of) > 0 {
+		l.of = make([]Column, len(of))
+		for i, table := range of {
+			l.of[i] = Column(table)
+		}
+	}
+
+	return l
+}
+
+// Sql generates the row locking clause, such as `FOR UPDATE OF "users" SKIP LOCKED`.
+// Returns an error if the dialect of the SqlOptions doesn't support row locking or the lock strength (MySQL has no key locks).
+func (l lock) Sql(options *driver.SqlOptions) (string, []any, error) {
+	if options.Dialect == driver.DialectSqlite || options.Dialect == driver.DialectMssql {
+		return "", nil, fmt.Errorf("row locking: %w %q", ErrUnsupportedDialect, options.Dialect)
+	}
+
+	if options.Dialect == driver.DialectMysql && (l.strength == lockNoKeyUpdate || l.strength == lockKeyShare) {
+		return "", nil, fmt.Errorf("row locking %s: %w %q", l.strength, ErrUnsupportedDialect, options.Dialect)
+	}
+
+	var buf strings.Builder
+	var args []any
+
+	buf.WriteString("FOR ")
+	buf.WriteString(l.strength.String())
+
+	if len(l.of) > 0 {
+		sql, ofArgs, err := concatFields(l.of, options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		args = append(args, ofArgs...)
+		buf.WriteString(" OF ")
+		buf.WriteString(sql)
+	}
+
+	if l.wait != lockWaitDefault {
+		buf.WriteByte(' ')
+		buf.WriteString(l.wait.String())
+	}
+
+	return buf.String(), args, nil
+}
+
+// String returns the SQL representation of the lockStrength, such as "UPDATE" or "KEY SHARE".
+func (l lockStrength) String() string {
+	switch l {
+	case lockNoKeyUpdate:
+		return "NO KEY UPDATE"
+	case lockShare:
+		return "SHARE"
+	case lockKeyShare:
+		return "KEY SHARE"
+	}
+
+	return "UPDATE"
+}
+
+// String returns the SQL representation of the lockWaitType, such as "NOWAIT" or "SKIP LOCKED".
+func (l lockWaitType) String() string {
+	switch l {
+	case lockNoWait:
+		return "NOWAIT"
+	case lockSkipLocked:
+		return "SKIP LOCKED"
+	}
+
+	return ""
+}
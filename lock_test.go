@@ -0,0 +1,108 @@
+package op
+
+import (
+	"testing"
+
+	"github.com/xsqrty/op/driver"
+	"github.com/xsqrty/op/internal/testutil"
+)
+
+func TestLock(t *testing.T) {
+	t.Parallel()
+	testutil.RunCases(t, options, []testutil.TestCase{
+		{
+			Name:         "for_update",
+			Builder:      Select("id").From("jobs").Where(Eq("status", "new")).ForUpdate(),
+			ExpectedSql:  `SELECT "id" FROM "jobs" WHERE "status" = ? FOR UPDATE`,
+			ExpectedArgs: []any{"new"},
+		},
+		{
+			Name: "for_update_skip_locked",
+			Builder: Select("id").
+				From("jobs").
+				Where(Eq("status", "new")).
+				OrderBy(Asc("created_at")).
+				Limit(10).
+				ForUpdate().
+				SkipLocked(),
+			ExpectedSql:  `SELECT "id" FROM "jobs" WHERE "status" = ? ORDER BY "created_at" ASC LIMIT ? FOR UPDATE SKIP LOCKED`,
+			ExpectedArgs: []any{"new", uint64(10)},
+		},
+		{
+			Name:         "for_no_key_update_nowait",
+			Builder:      Select().From("users").ForNoKeyUpdate().NoWait(),
+			ExpectedSql:  `SELECT * FROM "users" FOR NO KEY UPDATE NOWAIT`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "for_share_of",
+			Builder:      Select().From("users").Join("roles", Eq("users.role_id", Column("roles.id"))).ForShare("users"),
+			ExpectedSql:  `SELECT * FROM "users" JOIN "roles" ON "users"."role_id" = "roles"."id" FOR SHARE OF "users"`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name: "many_locks",
+			Builder: Select().
+				From("users").
+				Join("roles", Eq("users.role_id", Column("roles.id"))).
+				ForUpdate("users").
+				SkipLocked().
+				ForKeyShare("roles").
+				NoWait(),
+			ExpectedSql:  `SELECT * FROM "users" JOIN "roles" ON "users"."role_id" = "roles"."id" FOR UPDATE OF "users" SKIP LOCKED FOR KEY SHARE OF "roles" NOWAIT`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "error_nowait_without_lock",
+			Builder:      Select().From("users").NoWait(),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  "NOWAIT requires a row locking clause",
+		},
+		{
+			Name:         "error_skip_locked_without_lock",
+			Builder:      Select().From("users").SkipLocked(),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  "SKIP LOCKED requires a row locking clause",
+		},
+		{
+			Name:         "error_of",
+			Builder:      Select().From("users").ForUpdate("a+b"),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `target "a+b" contains illegal character '+'`,
+		},
+		{
+			Name:         "error_dialect",
+			Builder:      Select().From("users").ForUpdate(),
+			SqlOptions:   testutil.NewDialectOptions(driver.DialectSqlite),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `row locking: unsupported in dialect "sqlite"`,
+		},
+		{
+			Name:         "for_share_mysql",
+			Builder:      Select().From("users").ForShare().SkipLocked(),
+			SqlOptions:   testutil.NewDialectOptions(driver.DialectMysql),
+			ExpectedSql:  `SELECT * FROM "users" FOR SHARE SKIP LOCKED`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "error_no_key_update_mysql",
+			Builder:      Select().From("users").ForNoKeyUpdate(),
+			SqlOptions:   testutil.NewDialectOptions(driver.DialectMysql),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `row locking NO KEY UPDATE: unsupported in dialect "mysql"`,
+		},
+		{
+			Name:         "error_key_share_mysql",
+			Builder:      Select().From("users").ForKeyShare(),
+			SqlOptions:   testutil.NewDialectOptions(driver.DialectMysql),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `row locking KEY SHARE: unsupported in dialect "mysql"`,
+		},
+	})
+}
@@ -0,0 +1,354 @@
+package op
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/xsqrty/op/driver"
+)
+
+// MergeBuilder provides an interface for building SQL MERGE statements with USING source and WHEN branches.
+type MergeBuilder interface {
+	// Cte adds a named common table expression to the WITH clause, optionally with the list of its columns.
+	Cte(name string, query driver.Sqler, columns ...string) MergeBuilder
+	// CteRecursive adds a named common table expression to the WITH clause and marks the clause as RECURSIVE.
+	CteRecursive(name string, query driver.Sqler, columns ...string) MergeBuilder
+	// Using sets the source table or subquery of the MERGE statement and the join condition with the target table.
+	Using(source any, on driver.Sqler) MergeBuilder
+	// WhenMatched adds a WHEN MATCHED branch with the optional condition and the action to perform.
+	WhenMatched(condition driver.Sqler, action MergeAction) MergeBuilder
+	// WhenNotMatched adds a WHEN NOT MATCHED branch with the optional condition and the action to perform.
+	WhenNotMatched(condition driver.Sqler, action MergeAction) MergeBuilder
+	// PreparedSql generates a prepared SQL statement with placeholders and arguments based on the provided SqlOptions.
+	PreparedSql(options *driver.SqlOptions) (string, []any, error)
+	// Sql generates the SQL string and associated arguments based on the provided SqlOptions.
+	Sql(options *driver.SqlOptions) (string, []any, error)
+}
+
+// MergeAction defines the action performed by the WHEN branch of the MERGE statement, such as UPDATE or INSERT.
+type MergeAction interface {
+	// Sql generates the action of the WHEN branch as an SQL string with its arguments.
+	Sql(options *driver.SqlOptions) (string, []any, error)
+}
+
+// mergeActionType defines the type of the action of the WHEN branch.
+type mergeActionType int
+
+// mergeAction represents the UPDATE, DELETE, INSERT or DO NOTHING action of the WHEN branch.
+// The UPDATE action is rendered by the updateBuilder without a target table.
+type mergeAction struct {
+	actionType mergeActionType
+	update     *updateBuilder
+	keys       []Column
+	values     []any
+}
+
+// mergeWhen represents a single WHEN branch of the MERGE statement with its condition and action.
+type mergeWhen struct {
+	matched   bool
+	condition driver.Sqler
+	action    MergeAction
+}
+
+// mergeBuilder is a structure for constructing SQL MERGE statements.
+type mergeBuilder struct {
+	ctes   ctes
+	into   Alias
+	source Alias
+	on     driver.Sqler
+	whens  []mergeWhen
+	err    error
+}
+
+// mergeUpdate represents the UPDATE action.
+// mergeDelete represents the DELETE action.
+// mergeInsert represents the INSERT action.
+// mergeDoNothing represents the DO NOTHING action.
+const (
+	mergeUpdate mergeActionType = iota
+	mergeDelete
+	mergeInsert
+	mergeDoNothing
+)
+
+var (
+	ErrNoMergeSource = errors.New("no merge source")
+	ErrNoMergeWhen   = errors.New("no merge WHEN clauses")
+)
+
+// ensures that MergeBuilder implements the PreparedSqler interface at compile-time.
+var _ driver.PreparedSqler = MergeBuilder(nil)
+
+// Merge creates a new MergeBuilder for the specified target table. The target must be a string or an Alias.
+func Merge(into any) MergeBuilder {
+	mb := &mergeBuilder{}
+	switch val := into.(type) {
+	case string:
+		mb.into = ColumnAlias(Column(val))
+	case Alias:
+		mb.into = val
+	default:
+		mb.err = fmt.Errorf("%w: %T must be a string or Alias", ErrUnsupportedType, into)
+	}
+
+	return mb
+}
+
+// MergeUpdate creates the UPDATE action of the WHEN MATCHED branch using the provided update fields and values.
+func MergeUpdate(updates Updates) MergeAction {
+	ub := &updateBuilder{}
+	ub.setUpdates(updates)
+	return &mergeAction{actionType: mergeUpdate, update: ub}
+}
+
+// MergeDelete creates the DELETE action of the WHEN MATCHED branch.
+func MergeDelete() MergeAction {
+	return &mergeAction{actionType: mergeDelete}
+}
+
+// MergeInsert creates the INSERT action of the WHEN NOT MATCHED branch using the provided columns and values.
+func MergeInsert(inserting Inserting) MergeAction {
+	ma := &mergeAction{actionType: mergeInsert}
+	for key, val := range inserting {
+		ma.keys = append(ma.keys, Column(key))
+		ma.values = append(ma.values, val)
+	}
+
+	return ma
+}
+
+// MergeDoNothing creates the DO NOTHING action, which can be used by both WHEN MATCHED and WHEN NOT MATCHED branches.
+func MergeDoNothing() MergeAction {
+	return &mergeAction{actionType: mergeDoNothing}
+}
+
+// Cte adds a common table expression with the given name, query, and optional columns to the WITH clause.
+func (mb *mergeBuilder) Cte(name string, query driver.Sqler, columns ...string) MergeBuilder {
+	mb.ctes.add(name, query, columns, false)
+	return mb
+}
+
+// CteRecursive adds a common table expression to the WITH clause and renders the clause as WITH RECURSIVE.
+func (mb *mergeBuilder) CteRecursive(name string, query driver.Sqler, columns ...string) MergeBuilder {
+	mb.ctes.add(name, query, columns, true)
+	return mb
+}
+
+// Using sets the source of the MERGE statement, which must be a string or an Alias, and the ON join condition.
+func (mb *mergeBuilder) Using(source any, on driver.Sqler) MergeBuilder {
+	switch val := source.(type) {
+	case string:
+		mb.source = ColumnAlias(Column(val))
+	case Alias:
+		mb.source = val
+	default:
+		mb.err = fmt.Errorf("%w: %T must be a string or Alias", ErrUnsupportedType, source)
+		return mb
+	}
+
+	mb.on = on
+	return mb
+}
+
+// WhenMatched appends a WHEN MATCHED branch and returns the updated MergeBuilder. The condition can be nil.
+func (mb *mergeBuilder) WhenMatched(condition driver.Sqler, action MergeAction) MergeBuilder {
+	mb.whens = append(mb.whens, mergeWhen{matched: true, condition: condition, action: action})
+	return mb
+}
+
+// WhenNotMatched appends a WHEN NOT MATCHED branch and returns the updated MergeBuilder. The condition can be nil.
+func (mb *mergeBuilder) WhenNotMatched(condition driver.Sqler, action MergeAction) MergeBuilder {
+	mb.whens = append(mb.whens, mergeWhen{matched: false, condition: condition, action: action})
+	return mb
+}
+
+// Sql generates the MERGE statement with common table expressions, target, source, join condition and WHEN branches.
+// SQL Server requires the statement to be terminated with a semicolon.
+// Returns an error if the dialect of the SqlOptions doesn't support the MERGE statement (sqlite and MySQL).
+func (mb *mergeBuilder) Sql(options *driver.SqlOptions) (string, []any, error) {
+	if mb.err != nil {
+		return "", nil, mb.err
+	}
+
+	if options.Dialect != "" && options.Dialect != driver.DialectPostgres && options.Dialect != driver.DialectMssql {
+		return "", nil, fmt.Errorf("merge: %w %q", ErrUnsupportedDialect, options.Dialect)
+	}
+
+	if mb.source == nil {
+		return "", nil, fmt.Errorf("merge: %w", ErrNoMergeSource)
+	}
+
+	if mb.on == nil {
+		return "", nil, fmt.Errorf("merge: USING clause requires an ON clause to specify join condition")
+	}
+
+	if len(mb.whens) == 0 {
+		return "", nil, fmt.Errorf("merge: %w", ErrNoMergeWhen)
+	}
+
+	var buf strings.Builder
+	var args []any
+
+	if len(mb.ctes.items) > 0 {
+		sqlCtes, ctesArgs, err := mb.ctes.Sql(options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		args = append(args, ctesArgs...)
+		buf.WriteString(sqlCtes)
+		buf.WriteByte(' ')
+	}
+
+	buf.WriteString("MERGE INTO ")
+	sqlInto, intoArgs, err := mb.into.Sql(options)
+	if err != nil {
+		return "", nil, err
+	}
+
+	args = append(args, intoArgs...)
+	buf.WriteString(sqlInto)
+
+	buf.WriteString(" USING ")
+	sqlSource, sourceArgs, err := mb.source.Sql(options)
+	if err != nil {
+		return "", nil, err
+	}
+
+	args = append(args, sourceArgs...)
+	buf.WriteString(sqlSource)
+
+	buf.WriteString(" ON ")
+	sqlOn, onArgs, err := mb.on.Sql(options)
+	if err != nil {
+		return "", nil, err
+	}
+
+	args = append(args, onArgs...)
+	buf.WriteString(sqlOn)
+
+	for i := range mb.whens {
+		sqlWhen, whenArgs, err := mb.whens[i].Sql(options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		args = append(args, whenArgs...)
+		buf.WriteByte(' ')
+		buf.WriteString(sqlWhen)
+	}
+
+	if options.Dialect == driver.DialectMssql {
+		buf.WriteByte(';')
+	}
+
+	return buf.String(), args, nil
+}
+
+// PreparedSql generates a parameterized SQL string and corresponding arguments based on the provided SqlOptions.
+func (mb *mergeBuilder) PreparedSql(options *driver.SqlOptions) (string, []any, error) {
+	return driver.Sql(mb, options)
+}
+
+// Sql generates the WHEN branch, such as `WHEN MATCHED AND ... THEN UPDATE SET ...`.
+// Returns an error if the action is not allowed in the branch.
+func (mw mergeWhen) Sql(options *driver.SqlOptions) (string, []any, error) {
+	if mw.action == nil {
+		return "", nil, fmt.Errorf("merge: WHEN %s clause requires an action", mw.kind())
+	}
+
+	if ma, ok := mw.action.(*mergeAction); ok {
+		if (ma.actionType == mergeInsert && mw.matched) ||
+			((ma.actionType == mergeUpdate || ma.actionType == mergeDelete) && !mw.matched) {
+			return "", nil, fmt.Errorf("merge: %s action is not allowed in WHEN %s clause", ma.actionType, mw.kind())
+		}
+	}
+
+	var buf strings.Builder
+	var args []any
+
+	buf.WriteString("WHEN ")
+	buf.WriteString(mw.kind())
+
+	if mw.condition != nil {
+		sqlCond, condArgs, err := mw.condition.Sql(options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		args = append(args, condArgs...)
+		buf.WriteString(" AND ")
+		buf.WriteString(sqlCond)
+	}
+
+	buf.WriteString(" THEN ")
+	sqlAction, actionArgs, err := mw.action.Sql(options)
+	if err != nil {
+		return "", nil, err
+	}
+
+	args = append(args, actionArgs...)
+	buf.WriteString(sqlAction)
+
+	return buf.String(), args, nil
+}
+
+// kind returns the SQL representation of the branch kind, such as "MATCHED" or "NOT MATCHED".
+func (mw mergeWhen) kind() string {
+	if mw.matched {
+		return "MATCHED"
+	}
+
+	return "NOT MATCHED"
+}
+
+// Sql generates the action of the WHEN branch, such as `UPDATE SET ...` or `INSERT (...) VALUES (...)`.
+// SQL Server has no DO NOTHING action, the branch should be omitted instead.
+func (ma *mergeAction) Sql(options *driver.SqlOptions) (string, []any, error) {
+	switch ma.actionType {
+	case mergeUpdate:
+		if len(ma.update.updatesKeys) == 0 {
+			return "", nil, fmt.Errorf("merge update: %w", ErrFieldsEmpty)
+		}
+
+		return ma.update.Sql(options)
+	case mergeInsert:
+		if len(ma.keys) == 0 {
+			return "INSERT DEFAULT VALUES", nil, nil
+		}
+
+		sqlKeys, args, err := concatFields(ma.keys, options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		sqlVals, valsArgs, err := list(ma.values).Sql(options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		args = append(args, valsArgs...)
+		return "INSERT (" + sqlKeys + ") VALUES (" + sqlVals + ")", args, nil
+	case mergeDoNothing:
+		if options.Dialect == driver.DialectMssql {
+			return "", nil, fmt.Errorf("merge do nothing: %w %q", ErrUnsupportedDialect, options.Dialect)
+		}
+	}
+
+	return ma.actionType.String(), nil, nil
+}
+
+// String returns the SQL representation of the mergeActionType, such as "UPDATE" or "DO NOTHING".
+func (m mergeActionType) String() string {
+	switch m {
+	case mergeUpdate:
+		return "UPDATE"
+	case mergeDelete:
+		return "DELETE"
+	case mergeInsert:
+		return "INSERT"
+	}
+
+	return "DO NOTHING"
+}
@@ -0,0 +1,195 @@
+package op
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xsqrty/op/driver"
+	"github.com/xsqrty/op/internal/testutil"
+)
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+	testutil.RunCases(t, options, []testutil.TestCase{
+		{
+			Name: "merge",
+			Builder: Merge("users").
+				Using(As("s", Select().From("staging")), Eq("users.id", Column("s.id"))).
+				WhenMatched(Eq("s.deleted", true), MergeDelete()).
+				WhenMatched(nil, MergeUpdate(Updates{"name": Column("s.name")})).
+				WhenNotMatched(Eq("s.deleted", false), MergeInsert(Inserting{"name": Column("s.name")})).
+				WhenNotMatched(nil, MergeDoNothing()),
+			ExpectedSql:  `MERGE INTO "users" USING (SELECT * FROM "staging") AS "s" ON "users"."id" = "s"."id" WHEN MATCHED AND "s"."deleted" = ? THEN DELETE WHEN MATCHED THEN UPDATE SET "name"="s"."name" WHEN NOT MATCHED AND "s"."deleted" = ? THEN INSERT ("name") VALUES ("s"."name") WHEN NOT MATCHED THEN DO NOTHING`,
+			ExpectedArgs: []any{true, false},
+		},
+		{
+			Name: "merge_values",
+			Builder: Merge(ColumnAlias("counters")).
+				Using("events", Eq("counters.key", Column("events.key"))).
+				WhenMatched(nil, MergeUpdate(Updates{"value": Add(Column("counters.value"), 1)})).
+				WhenNotMatched(nil, MergeInsert(Inserting{"value": 1})),
+			ExpectedSql:  `MERGE INTO "counters" USING "events" ON "counters"."key" = "events"."key" WHEN MATCHED THEN UPDATE SET "value"=("counters"."value"+?) WHEN NOT MATCHED THEN INSERT ("value") VALUES (?)`,
+			ExpectedArgs: []any{1, 1},
+		},
+		{
+			Name: "merge_default_values",
+			Builder: Merge("users").
+				Cte("s", Select("id").From("staging")).
+				Using("s", Eq("users.id", Column("s.id"))).
+				WhenNotMatched(nil, MergeInsert(Inserting{})),
+			ExpectedSql:  `WITH "s" AS (SELECT "id" FROM "staging") MERGE INTO "users" USING "s" ON "users"."id" = "s"."id" WHEN NOT MATCHED THEN INSERT DEFAULT VALUES`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name: "merge_postgres",
+			Builder: Merge("users").
+				Using("staging", Eq("users.id", Column("staging.id"))).
+				WhenMatched(nil, MergeDelete()),
+			SqlOptions:   testutil.NewDialectOptions(driver.DialectPostgres),
+			ExpectedSql:  `MERGE INTO "users" USING "staging" ON "users"."id" = "staging"."id" WHEN MATCHED THEN DELETE`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name: "merge_mssql",
+			Builder: Merge("users").
+				Using("staging", Eq("users.id", Column("staging.id"))).
+				WhenMatched(nil, MergeUpdate(Updates{"name": Column("staging.name")})).
+				WhenNotMatched(nil, MergeInsert(Inserting{"name": Column("staging.name")})),
+			SqlOptions:   testutil.NewDialectOptions(driver.DialectMssql),
+			ExpectedSql:  `MERGE INTO "users" USING "staging" ON "users"."id" = "staging"."id" WHEN MATCHED THEN UPDATE SET "name"="staging"."name" WHEN NOT MATCHED THEN INSERT ("name") VALUES ("staging"."name");`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name: "error_mssql_do_nothing",
+			Builder: Merge("users").
+				Using("staging", Eq("users.id", Column("staging.id"))).
+				WhenNotMatched(nil, MergeDoNothing()),
+			SqlOptions:   testutil.NewDialectOptions(driver.DialectMssql),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `merge do nothing: unsupported in dialect "mssql"`,
+		},
+		{
+			Name: "error_dialect",
+			Builder: Merge("users").
+				Using("staging", Eq("users.id", Column("staging.id"))).
+				WhenMatched(nil, MergeDelete()),
+			SqlOptions:   testutil.NewDialectOptions(driver.DialectSqlite),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `merge: unsupported in dialect "sqlite"`,
+		},
+		{
+			Name:         "error_into_type",
+			Builder:      Merge(1),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  "unknown type: int must be a string or Alias",
+		},
+		{
+			Name:         "error_source_type",
+			Builder:      Merge("users").Using(1, nil),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  "unknown type: int must be a string or Alias",
+		},
+		{
+			Name:         "error_no_source",
+			Builder:      Merge("users").WhenMatched(nil, MergeDelete()),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  "merge: no merge source",
+		},
+		{
+			Name:         "error_no_on",
+			Builder:      Merge("users").Using("staging", nil).WhenMatched(nil, MergeDelete()),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  "merge: USING clause requires an ON clause to specify join condition",
+		},
+		{
+			Name:         "error_no_when",
+			Builder:      Merge("users").Using("staging", Eq("users.id", Column("staging.id"))),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  "merge: no merge WHEN clauses",
+		},
+		{
+			Name: "error_no_action",
+			Builder: Merge("users").
+				Using("staging", Eq("users.id", Column("staging.id"))).
+				WhenNotMatched(nil, nil),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  "merge: WHEN NOT MATCHED clause requires an action",
+		},
+		{
+			Name: "error_insert_matched",
+			Builder: Merge("users").
+				Using("staging", Eq("users.id", Column("staging.id"))).
+				WhenMatched(nil, MergeInsert(Inserting{"id": 1})),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  "merge: INSERT action is not allowed in WHEN MATCHED clause",
+		},
+		{
+			Name: "error_update_not_matched",
+			Builder: Merge("users").
+				Using("staging", Eq("users.id", Column("staging.id"))).
+				WhenNotMatched(nil, MergeUpdate(Updates{"id": 1})),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  "merge: UPDATE action is not allowed in WHEN NOT MATCHED clause",
+		},
+		{
+			Name: "error_update_empty",
+			Builder: Merge("users").
+				Using("staging", Eq("users.id", Column("staging.id"))).
+				WhenMatched(nil, MergeUpdate(Updates{})),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  "merge update: fields is empty",
+		},
+		{
+			Name: "error_condition",
+			Builder: Merge("users").
+				Using("staging", Eq("users.id", Column("staging.id"))).
+				WhenMatched(Eq("a+b", 1), MergeDelete()),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `target "a+b" contains illegal character '+'`,
+		},
+		{
+			Name: "error_insert_column",
+			Builder: Merge("users").
+				Using("staging", Eq("users.id", Column("staging.id"))).
+				WhenNotMatched(nil, MergeInsert(Inserting{"a+b": 1})),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `target "a+b" contains illegal character '+'`,
+		},
+	})
+}
+
+func TestMergePreparedSql(t *testing.T) {
+	t.Parallel()
+	options := testutil.NewDialectOptions(driver.DialectPostgres)
+	options.PlaceholderFormat = func(n int) string {
+		return "$" + strconv.Itoa(n)
+	}
+
+	sql, args, err := Merge("users").
+		Using("staging", Eq("users.id", Column("staging.id"))).
+		WhenMatched(Eq("staging.deleted", true), MergeDelete()).
+		WhenNotMatched(nil, MergeInsert(Inserting{"id": Column("staging.id")})).
+		PreparedSql(options)
+
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		`MERGE INTO "users" USING "staging" ON "users"."id" = "staging"."id" WHEN MATCHED AND "staging"."deleted" = $1 THEN DELETE WHEN NOT MATCHED THEN INSERT ("id") VALUES ("staging"."id")`,
+		sql,
+	)
+	require.Equal(t, []any{true}, args)
+}
@@ -0,0 +1,415 @@
+package migrate
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/xsqrty/op"
+	"github.com/xsqrty/op/db"
+	"github.com/xsqrty/op/driver"
+	"github.com/xsqrty/op/orm"
+)
+
+// Migrator defines an interface for applying and reverting versioned migrations.
+type Migrator interface {
+	// Status returns the list of known and applied migrations ordered by version.
+	Status(ctx context.Context) ([]Status, error)
+	// Up applies all pending migrations.
+	Up(ctx context.Context) error
+	// UpTo applies the pending migrations up to and including the specified version.
+	UpTo(ctx context.Context, version uint64) error
+	// Down reverts the last applied migration.
+	Down(ctx context.Context) error
+	// DownTo reverts the applied migrations with versions greater than the specified version.
+	// The version 0 reverts all applied migrations.
+	DownTo(ctx context.Context, version uint64) error
+}
+
+// Step defines a function applying or reverting a migration.
+// The exec runs the statements within the transaction of the step.
+type Step func(ctx context.Context, exec db.QueryExec) error
+
+// Option defines a functional option for configuring a Migrator instance.
+type Option func(m *migrator)
+
+// Migration describes a single versioned migration with its up and down steps.
+type Migration struct {
+	Version uint64
+	Name    string
+	Up      Step
+	Down    Step
+}
+
+// Status describes the state of the migration.
+type Status struct {
+	Version   uint64
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// migrator is an implementation of Migrator, which records applied versions in the table of the connection pool.
+type migrator struct {
+	pool       db.ConnPool
+	migrations []Migration
+	table      string
+	lockTable  string
+}
+
+// appliedMigration represents a row of the table of applied versions.
+type appliedMigration struct {
+	Version   int64     `op:"version,primary"`
+	Name      string    `op:"name"`
+	AppliedAt time.Time `op:"applied_at"`
+}
+
+// DefaultTable is the default name of the table of applied versions.
+const DefaultTable = "op_migrations"
+
+// lockRowId is the primary key of the single row of the lock table.
+const lockRowId = 1
+
+var (
+	ErrInvalidVersion   = errors.New("invalid migration version")
+	ErrDuplicateVersion = errors.New("duplicate migration version")
+	ErrUnknownVersion   = errors.New("unknown migration version")
+	ErrNoUp             = errors.New("migration has no up step")
+	ErrNoDown           = errors.New("migration has no down step")
+	ErrNameMismatch     = errors.New("migration names of up and down files mismatch")
+)
+
+// New creates a Migrator applying the migrations using the provided connection pool.
+// Migrations are ordered by version, which must be unique and greater than zero.
+func New(pool db.ConnPool, migrations []Migration, options ...Option) (Migrator, error) {
+	m := &migrator{
+		pool:       pool,
+		migrations: slices.Clone(migrations),
+		table:      DefaultTable,
+	}
+
+	for _, option := range options {
+		option(m)
+	}
+
+	if m.lockTable == "" {
+		m.lockTable = m.table + "_lock"
+	}
+
+	slices.SortFunc(m.migrations, func(a, b Migration) int {
+		return cmp.Compare(a.Version, b.Version)
+	})
+
+	for i := range m.migrations {
+		mig := m.migrations[i]
+		if mig.Version == 0 || mig.Version > math.MaxInt64 {
+			return nil, fmt.Errorf("%w: %d", ErrInvalidVersion, mig.Version)
+		}
+
+		if i > 0 && m.migrations[i-1].Version == mig.Version {
+			return nil, fmt.Errorf("%w: %d", ErrDuplicateVersion, mig.Version)
+		}
+
+		if mig.Up == nil {
+			return nil, fmt.Errorf("%w: %d", ErrNoUp, mig.Version)
+		}
+	}
+
+	return m, nil
+}
+
+// WithTable sets the name of the table of applied versions. The default is DefaultTable.
+func WithTable(name string) Option {
+	return func(m *migrator) {
+		m.table = name
+	}
+}
+
+// WithLockTable sets the name of the lock table used by dialects without advisory locks.
+// The default is the name of the table of applied versions with the "_lock" suffix.
+func WithLockTable(name string) Option {
+	return func(m *migrator) {
+		m.lockTable = name
+	}
+}
+
+// Status returns the known migrations merged with the applied ones, ordered by version.
+// The applied migrations, which are unknown to the Migrator, are included with the name from the table.
+func (m *migrator) Status(ctx context.Context) ([]Status, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for i := range m.migrations {
+		status := Status{Version: m.migrations[i].Version, Name: m.migrations[i].Name}
+		if am, ok := applied[status.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = am.AppliedAt
+			delete(applied, status.Version)
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	for version, am := range applied {
+		statuses = append(statuses, Status{Version: version, Name: am.Name, Applied: true, AppliedAt: am.AppliedAt})
+	}
+
+	slices.SortFunc(statuses, func(a, b Status) int {
+		return cmp.Compare(a.Version, b.Version)
+	})
+
+	return statuses, nil
+}
+
+// Up applies all pending migrations in ascending order of versions.
+func (m *migrator) Up(ctx context.Context) error {
+	return m.upTo(ctx, math.MaxInt64)
+}
+
+// UpTo applies the pending migrations with versions less than or equal to the specified version.
+// Returns ErrUnknownVersion if the version doesn't belong to any migration.
+func (m *migrator) UpTo(ctx context.Context, version uint64) error {
+	if !m.known(version) {
+		return fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+	}
+
+	return m.upTo(ctx, version)
+}
+
+// Down reverts the last applied migration. Does nothing if there are no applied migrations.
+func (m *migrator) Down(ctx context.Context) error {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return err
+	}
+
+	var last uint64
+	for version := range applied {
+		last = max(last, version)
+	}
+
+	if last == 0 {
+		return nil
+	}
+
+	return m.downTo(ctx, last-1, applied)
+}
+
+// DownTo reverts the applied migrations with versions greater than the specified version in descending order.
+// Returns ErrUnknownVersion if the version is not zero and doesn't belong to any migration.
+func (m *migrator) DownTo(ctx context.Context, version uint64) error {
+	if version != 0 && !m.known(version) {
+		return fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+	}
+
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return err
+	}
+
+	return m.downTo(ctx, version, applied)
+}
+
+// upTo applies the pending migrations with versions less than or equal to the specified version.
+func (m *migrator) upTo(ctx context.Context, version uint64) error {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i := range m.migrations {
+		mig := m.migrations[i]
+		if mig.Version > version {
+			break
+		}
+
+		if _, ok := applied[mig.Version]; ok {
+			continue
+		}
+
+		err = m.pool.Transact(ctx, func(ctx context.Context) error {
+			isApplied, err := m.lockVersion(ctx, mig.Version)
+			if err != nil || isApplied {
+				return err
+			}
+
+			if err = mig.Up(ctx, m.pool); err != nil {
+				return err
+			}
+
+			_, err = orm.Exec(op.Insert(m.table, op.Inserting{
+				"version":    int64(mig.Version),
+				"name":       mig.Name,
+				"applied_at": time.Now().UTC(),
+			})).With(ctx, m.pool)
+
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("migration %d %s up: %w", mig.Version, mig.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// downTo reverts the applied migrations with versions greater than the specified version.
+func (m *migrator) downTo(ctx context.Context, version uint64, applied map[uint64]*appliedMigration) error {
+	var versions []uint64
+	for v := range applied {
+		if v > version {
+			versions = append(versions, v)
+		}
+	}
+
+	slices.Sort(versions)
+	slices.Reverse(versions)
+
+	for _, v := range versions {
+		i, ok := slices.BinarySearchFunc(m.migrations, v, func(mig Migration, v uint64) int {
+			return cmp.Compare(mig.Version, v)
+		})
+		if !ok {
+			return fmt.Errorf("%w: %d", ErrUnknownVersion, v)
+		}
+
+		mig := m.migrations[i]
+		if mig.Down == nil {
+			return fmt.Errorf("%w: %d", ErrNoDown, v)
+		}
+
+		err := m.pool.Transact(ctx, func(ctx context.Context) error {
+			isApplied, err := m.lockVersion(ctx, mig.Version)
+			if err != nil || !isApplied {
+				return err
+			}
+
+			if err = mig.Down(ctx, m.pool); err != nil {
+				return err
+			}
+
+			_, err = orm.Exec(op.Delete(m.table).Where(op.Eq("version", int64(mig.Version)))).With(ctx, m.pool)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("migration %d %s down: %w", mig.Version, mig.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// applied creates the tables of the Migrator if they don't exist and returns the applied migrations by versions.
+func (m *migrator) applied(ctx context.Context) (map[uint64]*appliedMigration, error) {
+	var err error
+	if m.pool.SqlOptions().Dialect == driver.DialectPostgres {
+		err = m.pool.Transact(ctx, m.init)
+	} else {
+		err = m.init(ctx)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := orm.Query[appliedMigration](op.Select().From(m.table)).GetMany(ctx, m.pool)
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make(map[uint64]*appliedMigration, len(rows))
+	for _, row := range rows {
+		applied[uint64(row.Version)] = row // nolint: gosec
+	}
+
+	return applied, nil
+}
+
+// init creates the table of applied versions and the lock table if they don't exist.
+// Postgres runs it in a transaction under the advisory lock, since concurrent CREATE TABLE IF NOT EXISTS statements
+// can conflict. Other dialects run it without a transaction, since sqlite can't upgrade the read lock of a deferred
+// transaction to the write lock while another connection is writing.
+func (m *migrator) init(ctx context.Context) error {
+	if m.pool.SqlOptions().Dialect == driver.DialectPostgres {
+		if err := m.lock(ctx); err != nil {
+			return err
+		}
+	}
+
+	_, err := orm.Exec(op.CreateTable(m.table).IfNotExists().Columns(
+		op.ColumnDef("version", op.TypeBigInt()).PrimaryKey(),
+		op.ColumnDef("name", op.TypeText()).NotNull(),
+		op.ColumnDef("applied_at", op.TypeTimestampTz()).NotNull(),
+	)).With(ctx, m.pool)
+	if err != nil {
+		return err
+	}
+
+	if m.pool.SqlOptions().Dialect == driver.DialectPostgres {
+		return nil
+	}
+
+	_, err = orm.Exec(op.CreateTable(m.lockTable).IfNotExists().Columns(
+		op.ColumnDef("id", op.TypeInteger()).PrimaryKey(),
+		op.ColumnDef("locked_at", op.TypeTimestampTz()),
+	)).With(ctx, m.pool)
+	if err != nil {
+		return err
+	}
+
+	_, err = orm.Exec(op.Insert(m.lockTable, op.Inserting{"id": lockRowId}).OnConflict("id", op.DoNothing())).
+		With(ctx, m.pool)
+
+	return err
+}
+
+// lockVersion takes the migration lock within the transaction of the context and reports whether the version is applied.
+// The check is repeated under the lock, so concurrent Migrators never apply or revert the same version twice.
+func (m *migrator) lockVersion(ctx context.Context, version uint64) (bool, error) {
+	if err := m.lock(ctx); err != nil {
+		return false, err
+	}
+
+	count, err := orm.Count(op.Select().From(m.table).Where(op.Eq("version", int64(version)))).With(ctx, m.pool) // nolint: gosec
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// lock takes the migration lock released at the end of the transaction of the context.
+// Postgres uses the transaction-level advisory lock, other dialects update the row of the lock table.
+func (m *migrator) lock(ctx context.Context) error {
+	if m.pool.SqlOptions().Dialect == driver.DialectPostgres {
+		_, err := orm.Exec(op.Select(op.Func("pg_advisory_xact_lock", m.lockKey()))).With(ctx, m.pool)
+		return err
+	}
+
+	_, err := orm.Exec(op.Update(m.lockTable, op.Updates{"locked_at": time.Now().UTC()}).Where(op.Eq("id", lockRowId))).
+		With(ctx, m.pool)
+
+	return err
+}
+
+// lockKey returns the key of the advisory lock derived from the name of the table of applied versions.
+func (m *migrator) lockKey() int64 {
+	h := fnv.New64a()
+	h.Write([]byte(m.table)) // nolint: errcheck, gosec
+	return int64(h.Sum64())  // nolint: gosec
+}
+
+// known reports whether the version belongs to one of the migrations.
+func (m *migrator) known(version uint64) bool {
+	return slices.ContainsFunc(m.migrations, func(mig Migration) bool {
+		return mig.Version == version
+	})
+}
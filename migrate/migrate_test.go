@@ -0,0 +1,241 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xsqrty/op"
+	"github.com/xsqrty/op/db"
+	"github.com/xsqrty/op/db/sqlite"
+	"github.com/xsqrty/op/orm"
+)
+
+var errStep = errors.New("step failed")
+
+func openSqlite(t *testing.T) db.ConnPool {
+	t.Helper()
+	pool, err := sqlite.Open(filepath.Join(t.TempDir(), "migrate.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		pool.Close() // nolint: errcheck, gosec
+	})
+
+	return pool
+}
+
+func testMigrations() []Migration {
+	return []Migration{
+		{
+			Version: 2,
+			Name:    "add_users_email",
+			Up:      SqlStep(`ALTER TABLE "users" ADD COLUMN "email" TEXT`),
+			Down:    SqlStep(`ALTER TABLE "users" DROP COLUMN "email"`),
+		},
+		{
+			Version: 1,
+			Name:    "create_users",
+			Up: func(ctx context.Context, exec db.QueryExec) error {
+				_, err := orm.Exec(op.CreateTable("users").Columns(
+					op.ColumnDef("id", op.TypeBigSerial()).PrimaryKey(),
+					op.ColumnDef("name", op.TypeText()).NotNull(),
+				)).With(ctx, exec)
+
+				return err
+			},
+			Down: func(ctx context.Context, exec db.QueryExec) error {
+				_, err := orm.Exec(op.DropTable("users")).With(ctx, exec)
+				return err
+			},
+		},
+		{
+			Version: 3,
+			Name:    "create_roles",
+			Up:      SqlStep(`CREATE TABLE "roles" ("id" INTEGER PRIMARY KEY); INSERT INTO "roles" ("id") VALUES (1)`),
+			Down:    SqlStep(`DROP TABLE "roles"`),
+		},
+	}
+}
+
+func applied(t *testing.T, m Migrator) []uint64 {
+	t.Helper()
+	statuses, err := m.Status(context.Background())
+	require.NoError(t, err)
+
+	var versions []uint64
+	for _, status := range statuses {
+		if status.Applied {
+			require.False(t, status.AppliedAt.IsZero())
+			versions = append(versions, status.Version)
+		}
+	}
+
+	return versions
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+	step := SqlStep("")
+	cases := []struct {
+		name       string
+		migrations []Migration
+		err        error
+	}{
+		{name: "zero_version", migrations: []Migration{{Version: 0, Up: step}}, err: ErrInvalidVersion},
+		{name: "big_version", migrations: []Migration{{Version: 1 << 63, Up: step}}, err: ErrInvalidVersion},
+		{
+			name:       "duplicate_version",
+			migrations: []Migration{{Version: 1, Up: step}, {Version: 2, Up: step}, {Version: 1, Up: step}},
+			err:        ErrDuplicateVersion,
+		},
+		{name: "no_up", migrations: []Migration{{Version: 1, Down: step}}, err: ErrNoUp},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := New(nil, c.migrations)
+			require.ErrorIs(t, err, c.err)
+		})
+	}
+}
+
+func TestMigrator(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	pool := openSqlite(t)
+	m, err := New(pool, testMigrations())
+	require.NoError(t, err)
+
+	statuses, err := m.Status(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []Status{
+		{Version: 1, Name: "create_users"},
+		{Version: 2, Name: "add_users_email"},
+		{Version: 3, Name: "create_roles"},
+	}, statuses)
+
+	require.NoError(t, m.UpTo(ctx, 2))
+	require.Equal(t, []uint64{1, 2}, applied(t, m))
+
+	_, err = pool.Exec(ctx, `INSERT INTO "users" ("name", "email") VALUES ('Alex', 'alex@example.com')`)
+	require.NoError(t, err)
+
+	require.NoError(t, m.Up(ctx))
+	require.Equal(t, []uint64{1, 2, 3}, applied(t, m))
+
+	require.NoError(t, m.Up(ctx))
+	require.Equal(t, []uint64{1, 2, 3}, applied(t, m))
+
+	require.NoError(t, m.Down(ctx))
+	require.Equal(t, []uint64{1, 2}, applied(t, m))
+
+	require.NoError(t, m.DownTo(ctx, 1))
+	require.Equal(t, []uint64{1}, applied(t, m))
+
+	_, err = pool.Exec(ctx, `SELECT email FROM "users"`)
+	require.ErrorContains(t, err, "no such column: email")
+
+	require.NoError(t, m.DownTo(ctx, 0))
+	require.Empty(t, applied(t, m))
+
+	require.NoError(t, m.Down(ctx))
+	require.ErrorIs(t, m.UpTo(ctx, 10), ErrUnknownVersion)
+	require.ErrorIs(t, m.DownTo(ctx, 10), ErrUnknownVersion)
+}
+
+func TestMigrator_Rollback(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	pool := openSqlite(t)
+	migrations := append(testMigrations()[1:2], Migration{
+		Version: 2,
+		Name:    "broken",
+		Up: func(ctx context.Context, exec db.QueryExec) error {
+			_, err := exec.Exec(ctx, `CREATE TABLE "broken" ("id" INTEGER)`)
+			require.NoError(t, err)
+			return errStep
+		},
+	})
+
+	m, err := New(pool, migrations)
+	require.NoError(t, err)
+
+	err = m.Up(ctx)
+	require.ErrorIs(t, err, errStep)
+	require.EqualError(t, err, "migration 2 broken up: step failed")
+	require.Equal(t, []uint64{1}, applied(t, m))
+
+	_, err = pool.Exec(ctx, `SELECT * FROM "broken"`)
+	require.ErrorContains(t, err, "no such table: broken")
+
+	require.NoError(t, m.DownTo(ctx, 0))
+	require.Empty(t, applied(t, m))
+}
+
+func TestMigrator_UnknownApplied(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	pool := openSqlite(t)
+	m, err := New(pool, testMigrations(), WithTable("schema_versions"))
+	require.NoError(t, err)
+	require.NoError(t, m.Up(ctx))
+
+	m, err = New(pool, testMigrations()[:2], WithTable("schema_versions"))
+	require.NoError(t, err)
+
+	statuses, err := m.Status(ctx)
+	require.NoError(t, err)
+	require.Len(t, statuses, 3)
+	require.Equal(t, "create_roles", statuses[2].Name)
+	require.True(t, statuses[2].Applied)
+
+	require.ErrorIs(t, m.Down(ctx), ErrUnknownVersion)
+}
+
+func TestMigrator_Concurrent(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	pool := openSqlite(t)
+	_, err := pool.Exec(ctx, `CREATE TABLE "runs" ("version" INTEGER)`)
+	require.NoError(t, err)
+
+	var migrations []Migration
+	for i := uint64(1); i <= 5; i++ {
+		migrations = append(migrations, Migration{
+			Version: i,
+			Up: func(ctx context.Context, exec db.QueryExec) error {
+				_, err := orm.Exec(op.Insert("runs", op.Inserting{"version": i})).With(ctx, exec)
+				return err
+			},
+		})
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, 4)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m, err := New(pool, migrations)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+
+			errs[i] = m.Up(ctx)
+		}()
+	}
+
+	wg.Wait()
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+
+	count, err := orm.Count(op.Select().From("runs")).With(ctx, pool)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), count)
+}
@@ -0,0 +1,89 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/xsqrty/op/db"
+)
+
+// fileNameRegexp matches the names of the migration files, such as "0001_create_users.up.sql".
+var fileNameRegexp = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// FromFS reads the migrations from the directory of the file system, such as embed.FS.
+// Files must be named as "<version>_<name>.up.sql" and "<version>_<name>.down.sql", the down file is optional.
+// Files not matching the pattern are ignored.
+func FromFS(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var migrations []Migration
+	byVersion := make(map[uint64]int)
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		matches := fileNameRegexp.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			continue
+		}
+
+		version, err := strconv.ParseUint(matches[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidVersion, entry.Name())
+		}
+
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		i, ok := byVersion[version]
+		if !ok {
+			i = len(migrations)
+			byVersion[version] = i
+			migrations = append(migrations, Migration{Version: version, Name: matches[2]})
+		}
+
+		if migrations[i].Name != matches[2] {
+			return nil, fmt.Errorf("%w: %s", ErrNameMismatch, entry.Name())
+		}
+
+		step := SqlStep(string(content))
+		if matches[3] == "up" {
+			migrations[i].Up = step
+		} else {
+			migrations[i].Down = step
+		}
+	}
+
+	for i := range migrations {
+		if migrations[i].Up == nil {
+			return nil, fmt.Errorf("%w: %d", ErrNoUp, migrations[i].Version)
+		}
+	}
+
+	return migrations, nil
+}
+
+// SqlStep creates a Step executing the SQL script. The script can contain several statements separated by semicolons.
+// Empty scripts do nothing.
+func SqlStep(sql string) Step {
+	return func(ctx context.Context, exec db.QueryExec) error {
+		if strings.TrimSpace(sql) == "" {
+			return nil
+		}
+
+		_, err := exec.Exec(ctx, sql)
+		return err
+	}
+}
@@ -0,0 +1,82 @@
+package migrate
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromFS(t *testing.T) {
+	t.Parallel()
+	fsys := fstest.MapFS{
+		"migrations/0002_add_email.up.sql":      {Data: []byte(`ALTER TABLE "users" ADD COLUMN "email" TEXT`)},
+		"migrations/0001_create_users.up.sql":   {Data: []byte(`CREATE TABLE "users" ("id" INTEGER PRIMARY KEY)`)},
+		"migrations/0001_create_users.down.sql": {Data: []byte(`DROP TABLE "users"`)},
+		"migrations/README.md":                  {Data: []byte(`readme`)},
+		"migrations/nested/0003_x.up.sql":       {Data: []byte(`SELECT 1`)},
+	}
+
+	migrations, err := FromFS(fsys, "migrations")
+	require.NoError(t, err)
+	require.Len(t, migrations, 2)
+	require.Equal(t, uint64(1), migrations[0].Version)
+	require.Equal(t, "create_users", migrations[0].Name)
+	require.NotNil(t, migrations[0].Up)
+	require.NotNil(t, migrations[0].Down)
+	require.Equal(t, uint64(2), migrations[1].Version)
+	require.Equal(t, "add_email", migrations[1].Name)
+	require.Nil(t, migrations[1].Down)
+
+	pool := openSqlite(t)
+	m, err := New(pool, migrations)
+	require.NoError(t, err)
+	require.NoError(t, m.Up(context.Background()))
+	require.Equal(t, []uint64{1, 2}, applied(t, m))
+
+	require.ErrorIs(t, m.DownTo(context.Background(), 0), ErrNoDown)
+}
+
+func TestFromFS_Errors(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name string
+		fsys fstest.MapFS
+		err  error
+	}{
+		{
+			name: "name_mismatch",
+			fsys: fstest.MapFS{
+				"1_create_users.up.sql":    {Data: []byte(`SELECT 1`)},
+				"1_create_people.down.sql": {Data: []byte(`SELECT 1`)},
+			},
+			err: ErrNameMismatch,
+		},
+		{
+			name: "no_up",
+			fsys: fstest.MapFS{
+				"1_create_users.down.sql": {Data: []byte(`SELECT 1`)},
+			},
+			err: ErrNoUp,
+		},
+		{
+			name: "invalid_version",
+			fsys: fstest.MapFS{
+				"99999999999999999999_create_users.up.sql": {Data: []byte(`SELECT 1`)},
+			},
+			err: ErrInvalidVersion,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := FromFS(c.fsys, ".")
+			require.ErrorIs(t, err, c.err)
+		})
+	}
+
+	_, err := FromFS(fstest.MapFS{}, "missing")
+	require.Error(t, err)
+}
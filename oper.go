@@ -107,27 +107,55 @@ func HasProps(key any, args ...any) driver.Sqler {
 }
 
 // In constructs an SQL "IN" operator expression with the given key and values, returning a Sqler for query building.
+// A single SelectBuilder or CompoundBuilder value is rendered as `IN (subquery)`.
 func In(key any, values ...any) driver.Sqler {
-	return &operator{key: key, operator: "IN", value: list(values), wrapValue: true}
+	return &operator{key: key, operator: "IN", value: inValue(values), wrapValue: true}
 }
 
 // Nin creates a SQL condition for the "NOT IN" operator with a key and a variadic list of values.
+// A single SelectBuilder or CompoundBuilder value is rendered as `NOT IN (subquery)`.
 func Nin(key any, values ...any) driver.Sqler {
-	return &operator{key: key, operator: "NOT IN", value: list(values), wrapValue: true}
+	return &operator{key: key, operator: "NOT IN", value: inValue(values), wrapValue: true}
 }
 
 // Sql generates a SQL query string, its arguments, and handles errors based on the operator's configuration and options provided.
+// Subqueries used as the key or the value are wrapped in parentheses.
+// The operator is rendered differently if the dialect of the options rewrites it (e.g. ILIKE in sqlite).
 func (op *operator) Sql(options *driver.SqlOptions) (string, []any, error) {
-	keySql, argsKey, err := exprOrCol(op.key, options)
+	key, err := colSqler(wrapSubquery(op.key))
 	if err != nil {
 		return "", nil, err
 	}
 
-	if op.value == nil {
+	value := op.value
+	if value != nil && !op.wrapValue {
+		value = wrapSubquery(value)
+	}
+
+	operands := []driver.Sqler{key}
+	if value != nil {
+		operands = append(operands, valSqler(value))
+	}
+
+	expr, err := dialectExpr(op.operator, operands, options)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if expr != nil {
+		return expr.Sql(options)
+	}
+
+	keySql, argsKey, err := key.Sql(options)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if value == nil {
 		return driver.Pure(keySql+" "+op.operator, argsKey...).Sql(options)
 	}
 
-	valSql, argsVal, err := exprOrVal(op.value, options)
+	valSql, argsVal, err := operands[1].Sql(options)
 	if err != nil {
 		return "", nil, err
 	}
@@ -141,3 +169,18 @@ func (op *operator) Sql(options *driver.SqlOptions) (string, []any, error) {
 
 	return driver.Pure(sqlValue, append(argsKey, argsVal...)...).Sql(options)
 }
+
+// inValue returns the value of the "IN" operator, which is either a single subquery or a list of values.
+// Subqueries inside the list are wrapped in parentheses as scalar subqueries.
+func inValue(values []any) any {
+	if len(values) == 1 && isSubquery(values[0]) {
+		return values[0]
+	}
+
+	items := make(list, len(values))
+	for i := range values {
+		items[i] = wrapSubquery(values[i])
+	}
+
+	return items
+}
@@ -209,3 +209,20 @@ func TestCountExec(t *testing.T) {
 	require.Equal(t, int64(0), count)
 	require.EqualError(t, err, "rows affected error")
 }
+
+func TestCountCompound(t *testing.T) {
+	t.Parallel()
+	expectedSql := `SELECT (COUNT(*)) AS "total_count" FROM (SELECT "id" FROM "users" UNION SELECT "id" FROM "archived_users") AS "users" LIMIT ?`
+	expectedArgs := []any{uint64(1)}
+
+	query := testutil.NewMockQueryExec()
+	query.Q.
+		On("QueryRow", mock.Anything, expectedSql, expectedArgs).
+		Return(testutil.NewMockRow(nil, []any{int64(12)}))
+
+	count, err := Count(
+		op.Union(op.Select("id").From("users"), op.Select("id").From("archived_users")),
+	).With(context.Background(), query)
+	require.NoError(t, err)
+	require.Equal(t, int64(12), count)
+}
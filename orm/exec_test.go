@@ -50,3 +50,29 @@ func TestExecError(t *testing.T) {
 	require.Nil(t, res)
 	require.EqualError(t, err, `target "a+b" contains illegal character '+'`)
 }
+
+func TestExecMerge(t *testing.T) {
+	t.Parallel()
+	expectedSql := `MERGE INTO "users" USING "staging" ON "users"."id" = "staging"."id" WHEN MATCHED AND "staging"."deleted" = ? THEN DELETE WHEN NOT MATCHED THEN DO NOTHING`
+	expectedArgs := []any{true}
+
+	executor := testutil.NewMockExecutor()
+	executor.On(
+		"Exec",
+		mock.Anything,
+		expectedSql,
+		expectedArgs,
+	).Return(testutil.NewMockExecResult(3, 0), nil)
+
+	res, err := Exec(
+		op.Merge("users").
+			Using("staging", op.Eq("users.id", op.Column("staging.id"))).
+			WhenMatched(op.Eq("staging.deleted", true), op.MergeDelete()).
+			WhenNotMatched(nil, op.MergeDoNothing()),
+	).With(context.Background(), executor)
+	require.NoError(t, err)
+
+	rowsCount, err := res.RowsAffected()
+	require.NoError(t, err)
+	require.Equal(t, int64(3), rowsCount)
+}
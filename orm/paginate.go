@@ -34,8 +34,17 @@ type Paginator[T any] interface {
 	RightJoin(table any, on driver.Sqler) Paginator[T]
 	// InnerJoin adds an INNER JOIN clause to the query using the specified table and condition.
 	InnerJoin(table any, on driver.Sqler) Paginator[T]
-	// CrossJoin adds a CROSS JOIN clause to the query with the specified table and ON condition, returning the Paginator instance.
-	CrossJoin(table any, on driver.Sqler) Paginator[T]
+	// FullJoin adds a FULL OUTER JOIN clause to the query using the specified table and join condition.
+	FullJoin(table any, on driver.Sqler) Paginator[T]
+	// CrossJoin adds a CROSS JOIN clause to the query with the specified table, returning the Paginator instance.
+	// The optional condition is kept for compatibility and rendered as the ON clause.
+	CrossJoin(table any, on ...driver.Sqler) Paginator[T]
+	// JoinLateral adds a JOIN LATERAL clause to the query using the specified subquery and join condition.
+	JoinLateral(table any, on driver.Sqler) Paginator[T]
+	// LeftJoinLateral adds a LEFT JOIN LATERAL clause to the query using the specified subquery and join condition.
+	LeftJoinLateral(table any, on driver.Sqler) Paginator[T]
+	// CrossJoinLateral adds a CROSS JOIN LATERAL clause to the query with the specified subquery.
+	CrossJoinLateral(table any) Paginator[T]
 	// GroupBy groups the items in the pagination based on the specified grouping criteria provided as arguments.
 	GroupBy(groups ...any) Paginator[T]
 	// LogQuery executes a query with logging by utilizing the provided LoggerHandler and returns a paginated result.
@@ -134,7 +143,8 @@ const (
 )
 
 // Paginate creates a Paginator instance for querying and paginating rows from the specified SQL table.
-func Paginate[T any](table string, request *PaginateRequest) Paginator[T] {
+// The table must be a string or an op.Alias, for example a named compound query `op.As("feed", op.Union(...))`.
+func Paginate[T any](table any, request *PaginateRequest) Paginator[T] {
 	return &paginate[T]{
 		request:     request,
 		rowsSb:      op.Select().From(table),
@@ -279,10 +289,34 @@ func (pg *paginate[T]) InnerJoin(table any, on driver.Sqler) Paginator[T] {
 	return pg
 }
 
-// CrossJoin adds a CROSS JOIN clause to the query with the specified table and ON condition.
+// FullJoin adds a FULL OUTER JOIN clause to the query using the specified table and ON condition.
+func (pg *paginate[T]) FullJoin(table any, on driver.Sqler) Paginator[T] {
+	pg.rowsSb.FullJoin(table, on)
+	return pg
+}
+
+// CrossJoin adds a CROSS JOIN clause to the query with the specified table.
 // It returns the paginator instance for chaining other query modifications.
-func (pg *paginate[T]) CrossJoin(table any, on driver.Sqler) Paginator[T] {
-	pg.rowsSb.CrossJoin(table, on)
+func (pg *paginate[T]) CrossJoin(table any, on ...driver.Sqler) Paginator[T] {
+	pg.rowsSb.CrossJoin(table, on...)
+	return pg
+}
+
+// JoinLateral adds a JOIN LATERAL clause to the query using the specified subquery and ON condition.
+func (pg *paginate[T]) JoinLateral(table any, on driver.Sqler) Paginator[T] {
+	pg.rowsSb.JoinLateral(table, on)
+	return pg
+}
+
+// LeftJoinLateral adds a LEFT JOIN LATERAL clause to the query using the specified subquery and ON condition.
+func (pg *paginate[T]) LeftJoinLateral(table any, on driver.Sqler) Paginator[T] {
+	pg.rowsSb.LeftJoinLateral(table, on)
+	return pg
+}
+
+// CrossJoinLateral adds a CROSS JOIN LATERAL clause to the query with the specified subquery.
+func (pg *paginate[T]) CrossJoinLateral(table any) Paginator[T] {
+	pg.rowsSb.CrossJoinLateral(table)
 	return pg
 }
 
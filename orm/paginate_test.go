@@ -19,6 +19,10 @@ type PaginateMockUser struct {
 	CompanyName string `op:"company_name"`
 }
 
+func lateralCompany(limit uint64) op.SelectBuilder {
+	return op.Select("name").From("companies").Where(op.Eq("companies.id", op.Column("users.company_id"))).Limit(limit)
+}
+
 func TestPaginateApi(t *testing.T) {
 	t.Parallel()
 	cases := []struct {
@@ -94,6 +98,77 @@ func TestPaginateApi(t *testing.T) {
 				WhiteList("id", "age", "name").
 				CrossJoin("companies", op.Eq("users.company_id", op.Column("companies.id"))),
 		},
+		{
+			name:              "cross_join_without_on",
+			expectedSql:       `SELECT * FROM (SELECT ("users"."name") AS "user_name" FROM "users" CROSS JOIN "companies") AS "result" LIMIT ?`,
+			expectedSqlCount:  `SELECT (COUNT(*)) AS "total_count" FROM (SELECT ("users"."name") AS "user_name" FROM "users" CROSS JOIN "companies") AS "result"`,
+			expectedArgs:      []any{uint64(1)},
+			expectedArgsCount: []any(nil),
+			builder: Paginate[PaginateMockUser](
+				"users",
+				&PaginateRequest{},
+			).Fields(op.As("user_name", op.Column("users.name"))).
+				WhiteList("id", "age", "name").
+				CrossJoin("companies"),
+		},
+		{
+			name:              "full_join",
+			expectedSql:       `SELECT * FROM (SELECT ("users"."name") AS "user_name" FROM "users" FULL OUTER JOIN "companies" USING ("company_id")) AS "result" LIMIT ?`,
+			expectedSqlCount:  `SELECT (COUNT(*)) AS "total_count" FROM (SELECT ("users"."name") AS "user_name" FROM "users" FULL OUTER JOIN "companies" USING ("company_id")) AS "result"`,
+			expectedArgs:      []any{uint64(1)},
+			expectedArgsCount: []any(nil),
+			builder: Paginate[PaginateMockUser](
+				"users",
+				&PaginateRequest{},
+			).Fields(op.As("user_name", op.Column("users.name"))).
+				WhiteList("id", "age", "name").
+				FullJoin("companies", op.Using("company_id")),
+		},
+		{
+			name:              "join_lateral",
+			expectedSql:       `SELECT * FROM (SELECT ("users"."name") AS "user_name" FROM "users" JOIN LATERAL (SELECT "name" FROM "companies" WHERE "companies"."id" = "users"."company_id" LIMIT ?) AS "company" ON "company"."name" = "users"."name") AS "result" LIMIT ?`,
+			expectedSqlCount:  `SELECT (COUNT(*)) AS "total_count" FROM (SELECT ("users"."name") AS "user_name" FROM "users" JOIN LATERAL (SELECT "name" FROM "companies" WHERE "companies"."id" = "users"."company_id" LIMIT ?) AS "company" ON "company"."name" = "users"."name") AS "result"`,
+			expectedArgs:      []any{uint64(1), uint64(1)},
+			expectedArgsCount: []any{uint64(1)},
+			builder: Paginate[PaginateMockUser](
+				"users",
+				&PaginateRequest{},
+			).Fields(op.As("user_name", op.Column("users.name"))).
+				WhiteList("id", "age", "name").
+				JoinLateral(
+					op.As("company", lateralCompany(1)),
+					op.Eq("company.name", op.Column("users.name")),
+				),
+		},
+		{
+			name:              "left_join_lateral",
+			expectedSql:       `SELECT * FROM (SELECT ("users"."name") AS "user_name" FROM "users" LEFT JOIN LATERAL (SELECT "name" FROM "companies" WHERE "companies"."id" = "users"."company_id" LIMIT ?) AS "company" ON "company"."name" = "users"."name") AS "result" LIMIT ?`,
+			expectedSqlCount:  `SELECT (COUNT(*)) AS "total_count" FROM (SELECT ("users"."name") AS "user_name" FROM "users" LEFT JOIN LATERAL (SELECT "name" FROM "companies" WHERE "companies"."id" = "users"."company_id" LIMIT ?) AS "company" ON "company"."name" = "users"."name") AS "result"`,
+			expectedArgs:      []any{uint64(2), uint64(1)},
+			expectedArgsCount: []any{uint64(2)},
+			builder: Paginate[PaginateMockUser](
+				"users",
+				&PaginateRequest{},
+			).Fields(op.As("user_name", op.Column("users.name"))).
+				WhiteList("id", "age", "name").
+				LeftJoinLateral(
+					op.As("company", lateralCompany(2)),
+					op.Eq("company.name", op.Column("users.name")),
+				),
+		},
+		{
+			name:              "cross_join_lateral",
+			expectedSql:       `SELECT * FROM (SELECT ("users"."name") AS "user_name" FROM "users" CROSS JOIN LATERAL (SELECT "name" FROM "companies" WHERE "companies"."id" = "users"."company_id" LIMIT ?) AS "company") AS "result" LIMIT ?`,
+			expectedSqlCount:  `SELECT (COUNT(*)) AS "total_count" FROM (SELECT ("users"."name") AS "user_name" FROM "users" CROSS JOIN LATERAL (SELECT "name" FROM "companies" WHERE "companies"."id" = "users"."company_id" LIMIT ?) AS "company") AS "result"`,
+			expectedArgs:      []any{uint64(3), uint64(1)},
+			expectedArgsCount: []any{uint64(3)},
+			builder: Paginate[PaginateMockUser](
+				"users",
+				&PaginateRequest{},
+			).Fields(op.As("user_name", op.Column("users.name"))).
+				WhiteList("id", "age", "name").
+				CrossJoinLateral(op.As("company", lateralCompany(3))),
+		},
 	}
 
 	for _, tc := range cases {
@@ -255,3 +330,32 @@ func TestPaginateFilterError(t *testing.T) {
 	require.Nil(t, res)
 	require.EqualError(t, err, `paginate: target "id" is not allowed`)
 }
+
+func TestPaginateCompound(t *testing.T) {
+	t.Parallel()
+	expectedSql := `SELECT * FROM (SELECT ("name") AS "user_name" FROM (SELECT "name" FROM "users" UNION SELECT "name" FROM "archived_users") AS "feed") AS "result" WHERE "user_name" = ? LIMIT ?`
+	expectedCounterSql := `SELECT (COUNT(*)) AS "total_count" FROM (SELECT ("name") AS "user_name" FROM (SELECT "name" FROM "users" UNION SELECT "name" FROM "archived_users") AS "feed") AS "result" WHERE "user_name" = ?`
+
+	query := testutil.NewMockQueryable()
+	query.
+		On("Query", mock.Anything, expectedSql, []any{"Alex", uint64(10)}).
+		Return(testutil.NewMockRows(nil, []db.Scanner{
+			testutil.NewMockRow(nil, []any{"Alex"}),
+		}), nil)
+
+	query.On("QueryRow", mock.Anything, expectedCounterSql, []any{"Alex"}).
+		Return(testutil.NewMockRow(nil, []any{uint64(1)}))
+
+	res, err := Paginate[PaginateMockUser](
+		op.As("feed", op.Union(op.Select("name").From("users"), op.Select("name").From("archived_users"))),
+		&PaginateRequest{Limit: 10, Filters: PaginateFilters{"user_name": "Alex"}},
+	).
+		WhiteList("user_name").
+		Fields(op.As("user_name", op.Column("name"))).
+		With(context.Background(), query)
+
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), res.TotalRows)
+	require.Len(t, res.Rows, 1)
+	require.Equal(t, "Alex", res.Rows[0].Name)
+}
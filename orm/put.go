@@ -8,6 +8,7 @@ import (
 
 	"github.com/xsqrty/op"
 	"github.com/xsqrty/op/cache"
+	"github.com/xsqrty/op/driver"
 )
 
 // PutBuilder provides methods to configure and execute an insert or update operation for a given model.
@@ -25,6 +26,18 @@ type put[T any] struct {
 	item   *T
 }
 
+// putStatement defines the statement executed by the put operation.
+type putStatement int
+
+// putUpsert represents the INSERT ... ON CONFLICT DO UPDATE statement returning the stored item.
+// putInsert represents the plain INSERT statement without the RETURNING clause.
+// putUpdate represents the UPDATE statement of the item matched by its primary key.
+const (
+	putUpsert putStatement = iota
+	putInsert
+	putUpdate
+)
+
 // putCache is a concurrent map used to store and retrieve cached ReturnableContainer instances for specific operations.
 var putCache sync.Map
 
@@ -37,8 +50,14 @@ func Put[T any](table string, model *T) PutBuilder[T] {
 }
 
 // With executes a query using context and database, updating the item with the result or returning an error if it fails.
+// MySQL has no RETURNING clause and its ON DUPLICATE KEY UPDATE fires on any unique key, so the item is updated
+// by its primary key or inserted, the generated primary key is taken from LastInsertId, and the item is reloaded.
 func (p *put[T]) With(ctx context.Context, db Queryable) error {
-	ret, err := p.getReturnable()
+	if db.SqlOptions().Dialect == driver.DialectMysql {
+		return p.withLastInsertId(ctx, db)
+	}
+
+	ret, _, err := p.getReturnable(putUpsert)
 	if err != nil {
 		return err
 	}
@@ -52,6 +71,75 @@ func (p *put[T]) With(ctx context.Context, db Queryable) error {
 	return nil
 }
 
+// withLastInsertId updates the item matched by its non-zero primary key, or inserts it if there is no such row,
+// and reloads the item by its primary key. The zero primary key of the item is populated from the LastInsertId.
+// A collision with another row on a unique key fails the insert and leaves the existing row unchanged.
+func (p *put[T]) withLastInsertId(ctx context.Context, db Queryable) error {
+	exec, ok := db.(Executable)
+	if !ok {
+		return fmt.Errorf("put into %s: %T must implement Executable for dialect %q", p.table, db, db.SqlOptions().Dialect)
+	}
+
+	insert, primary, err := p.getReturnable(putInsert)
+	if err != nil {
+		return err
+	}
+
+	md, err := getModelDetails(p.table, p.item)
+	if err != nil {
+		return err
+	}
+
+	reload := Query[T](op.Select().From(p.table).Where(op.Eq(md.primaryAsTag, primary.Interface()))).Log(p.logger)
+	if !primary.IsZero() {
+		update, _, err := p.getReturnable(putUpdate)
+		if err != nil {
+			return err
+		}
+
+		if _, err = Exec(update).Log(p.logger).With(ctx, exec); err != nil {
+			return err
+		}
+
+		// MySQL reports 0 affected rows for the unchanged row, so the row is looked up before the insert
+		upd, err := reload.GetOneOrNil(ctx, db)
+		if err != nil {
+			return err
+		}
+
+		if upd != nil {
+			*p.item = *upd
+			return nil
+		}
+	}
+
+	res, err := Exec(insert).Log(p.logger).With(ctx, exec)
+	if err != nil {
+		return err
+	}
+
+	if primary.IsZero() {
+		id, err := res.LastInsertId()
+		if err != nil {
+			return err
+		}
+
+		if err = setInsertId(primary, id); err != nil {
+			return fmt.Errorf("put into %s: %w", p.table, err)
+		}
+
+		reload = Query[T](op.Select().From(p.table).Where(op.Eq(md.primaryAsTag, primary.Interface()))).Log(p.logger)
+	}
+
+	upd, err := reload.GetOne(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	*p.item = *upd
+	return nil
+}
+
 // Log sets the LoggerHandler for the put operation to log queries, arguments, and errors, and returns the PutBuilder.
 func (p *put[T]) Log(lh LoggerHandler) PutBuilder[T] {
 	p.logger = lh
@@ -59,49 +147,71 @@ func (p *put[T]) Log(lh LoggerHandler) PutBuilder[T] {
 }
 
 // getReturnable processes the input item and generates a returnable SQL operation or an error if processing fails.
-func (p *put[T]) getReturnable() (op.Returnable, error) {
+// It also returns the value of the primary key field of the item, which is invalid if the model has no such field.
+func (p *put[T]) getReturnable(statement putStatement) (op.Returnable, reflect.Value, error) {
 	md, err := getModelDetails(p.table, p.item)
 	if err != nil {
-		return nil, err
+		return nil, reflect.Value{}, err
 	}
 
 	if md.primaryAsTag == "" {
-		return nil, fmt.Errorf("no primary key for model %s", p.table)
+		return nil, reflect.Value{}, fmt.Errorf("no primary key for model %s", p.table)
 	}
 
 	fields, ok := md.tags[p.table]
 	if !ok {
-		return nil, fmt.Errorf("no such target for model %s", p.table)
+		return nil, reflect.Value{}, fmt.Errorf("no such target for model %s", p.table)
 	}
 
 	setters, err := getSettersByTags(md, p.table, fields)
 	if err != nil {
-		return nil, err
+		return nil, reflect.Value{}, err
 	}
 
 	pointers, err := getKeysPointers(p.item, setters, fields)
 	if err != nil {
-		return nil, err
+		return nil, reflect.Value{}, err
 	}
 
 	args := cache.Args{}
 	usePrimaryKey := true
+	var primary reflect.Value
 
 	for i := range fields {
 		if md.tagsDetails[p.table][fields[i]].isAggregated {
 			continue
 		}
 
-		if fields[i] == md.primaryAsTag && pointers[i] != nil &&
-			reflect.ValueOf(pointers[i]).Elem().IsZero() {
-			usePrimaryKey = false
-			continue
+		if fields[i] == md.primaryAsTag && pointers[i] != nil {
+			primary = reflect.ValueOf(pointers[i]).Elem()
+			if primary.IsZero() {
+				usePrimaryKey = false
+				continue
+			}
 		}
 
 		args[fields[i]] = reflect.ValueOf(pointers[i]).Elem().Interface()
 	}
 
-	return p.getCache(md, pointers, fields, usePrimaryKey).Use(args), nil
+	if !primary.IsValid() {
+		return nil, reflect.Value{}, fmt.Errorf("no primary key field for model %s", p.table)
+	}
+
+	return p.getCache(md, pointers, fields, usePrimaryKey, statement).Use(args), primary, nil
+}
+
+// setInsertId assigns the id generated by the database to the integer primary key field.
+func setInsertId(primary reflect.Value, id int64) error {
+	switch primary.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		primary.SetInt(id)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		primary.SetUint(uint64(id))
+	default:
+		return fmt.Errorf("%s primary key can't be set from last insert id", primary.Type())
+	}
+
+	return nil
 }
 
 // getCache retrieves or initializes a cached ReturnableContainer based on metadata, fields, pointers, and key usage.
@@ -111,11 +221,18 @@ func (p *put[T]) getCache(
 	pointers []any,
 	fields []string,
 	usePrimaryKey bool,
+	statement putStatement,
 ) ReturnableContainer {
 	cacheKey := p.table
 	if usePrimaryKey {
 		cacheKey += "_primary"
 	}
+	switch statement {
+	case putInsert:
+		cacheKey += "_insert"
+	case putUpdate:
+		cacheKey += "_update"
+	}
 
 	typ := reflect.ValueOf(p.item).Type()
 	if cachedMap, ok := putCache.Load(cacheKey); ok {
@@ -140,13 +257,28 @@ func (p *put[T]) getCache(
 		}
 
 		inserting[fields[i]] = cache.Arg(fields[i])
-		updates[fields[i]] = op.Excluded(fields[i])
+		if statement == putUpdate {
+			if fields[i] != md.primaryAsTag {
+				updates[fields[i]] = cache.Arg(fields[i])
+			}
+		} else {
+			updates[fields[i]] = op.Excluded(fields[i])
+		}
 	}
 
-	insert := op.Insert(p.table, inserting).OnConflict(md.primaryAsTag, op.DoUpdate(updates))
-	insert.SetReturning(aliases)
+	var ret op.Returnable
+	switch statement {
+	case putInsert:
+		ret = op.Insert(p.table, inserting)
+	case putUpdate:
+		ret = op.Update(p.table, updates).Where(op.Eq(md.primaryAsTag, cache.Arg(md.primaryAsTag)))
+	default:
+		insert := op.Insert(p.table, inserting).OnConflict(md.primaryAsTag, op.DoUpdate(updates))
+		insert.SetReturning(aliases)
+		ret = insert
+	}
 
-	result := NewReturnableCache(insert)
+	result := NewReturnableCache(ret)
 	inner, _ := putCache.LoadOrStore(cacheKey, &sync.Map{})
 	inner.(*sync.Map).Store(typ, result)
 
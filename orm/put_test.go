@@ -2,6 +2,7 @@ package orm
 
 import (
 	"context"
+	"database/sql"
 	"testing"
 
 	"github.com/stretchr/testify/mock"
@@ -49,6 +50,19 @@ func TestPut(t *testing.T) {
 	}
 }
 
+func TestPutNotFound(t *testing.T) {
+	t.Parallel()
+	query := testutil.NewMockQueryable()
+	query.
+		On("QueryRow", mock.Anything, mock.Anything, mock.Anything).
+		Return(testutil.NewMockRow(sql.ErrNoRows, nil))
+
+	user := &PutMockUser{ID: 100, Name: "Alex"}
+	err := Put("users", user).With(context.Background(), query)
+	require.ErrorIs(t, err, ErrNotFound)
+	require.Equal(t, &PutMockUser{ID: 100, Name: "Alex"}, user)
+}
+
 func TestPutTagsDetails(t *testing.T) {
 	t.Parallel()
 	expectedSql := `INSERT INTO "users" ("name") VALUES (?) ON CONFLICT ("id") DO UPDATE SET "name"=EXCLUDED."name" RETURNING "users"."id","users"."name"`
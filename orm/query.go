@@ -2,6 +2,9 @@ package orm
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 
 	"github.com/xsqrty/op"
 	"github.com/xsqrty/op/db"
@@ -11,7 +14,10 @@ import (
 // QueryBuilder is a generic interface for building and executing database queries of type T.
 type QueryBuilder[T any] interface {
 	// GetOne retrieves a single result of type T from the database using the provided context and Queryable.
+	// Returns an error wrapping ErrNotFound if there are no rows.
 	GetOne(ctx context.Context, db Queryable) (*T, error)
+	// GetOneOrNil retrieves a single result of type T like GetOne, but returns nil without an error if there are no rows.
+	GetOneOrNil(ctx context.Context, db Queryable) (*T, error)
 	// GetMany retrieves multiple results of type T from the database as a slice using the provided context and Queryable.
 	GetMany(ctx context.Context, db Queryable) ([]*T, error)
 	// Log sets a LoggerHandler for logging SQL queries, arguments, and errors for debugging purposes.
@@ -27,6 +33,9 @@ type Queryable interface {
 	SqlOptions() *driver.SqlOptions
 }
 
+// ErrNotFound is returned by GetOne if the query selected no rows, it wraps the original error of the driver.
+var ErrNotFound = errors.New("not found")
+
 // query defines a generic type for constructing and executing SQL queries, encapsulating query logic and metadata.
 type query[T any] struct {
 	with        string
@@ -73,12 +82,26 @@ func (q *query[T]) GetOne(ctx context.Context, db Queryable) (*T, error) {
 
 	err = db.QueryRow(ctx, sql, args...).Scan(pointers...)
 	if err != nil {
+		if isNoRows(err) {
+			return nil, fmt.Errorf("%w: %w", ErrNotFound, err)
+		}
+
 		return nil, err
 	}
 
 	return result, nil
 }
 
+// GetOneOrNil fetches a single record like GetOne, returning nil and no error if the record is not found.
+func (q *query[T]) GetOneOrNil(ctx context.Context, db Queryable) (*T, error) {
+	result, err := q.GetOne(ctx, db)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+
+	return result, err
+}
+
 // GetMany retrieves multiple records from the database, mapping rows to instances of type T in the provided query context.
 func (q *query[T]) GetMany(ctx context.Context, db Queryable) ([]*T, error) {
 	result := make([]*T, 0)
@@ -143,6 +166,12 @@ func (q *query[T]) sql(db Queryable) (string, []any, error) {
 	return q.ret.PreparedSql(db.SqlOptions())
 }
 
+// isNoRows reports whether the error means that the query selected no rows.
+// The driver errors unclassified by the connection pool are matched by sql.ErrNoRows, which pgx.ErrNoRows also matches.
+func isNoRows(err error) bool {
+	return errors.Is(err, db.ErrNoRows) || errors.Is(err, sql.ErrNoRows)
+}
+
 // log logs the SQL query, its arguments, and any associated error using the logger if it's defined.
 func (q *query[T]) log(sql string, args []any, err error) {
 	if q.logger != nil {
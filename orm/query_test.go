@@ -2,6 +2,7 @@ package orm
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"testing"
 
@@ -90,6 +91,58 @@ func TestGetOneError(t *testing.T) {
 	require.EqualError(t, err, "sql syntax error")
 }
 
+func TestGetOneNotFound(t *testing.T) {
+	t.Parallel()
+	for name, noRowsErr := range map[string]error{
+		"sql":        sql.ErrNoRows,
+		"classified": &db.Error{Kind: db.ErrNoRows, Err: sql.ErrNoRows},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			query := testutil.NewMockQueryable()
+			query.
+				On(
+					"QueryRow",
+					mock.Anything,
+					`SELECT "users"."id","users"."name" FROM "users" LIMIT ?`,
+					[]any{uint64(1)},
+				).
+				Return(testutil.NewMockRow(noRowsErr, nil))
+
+			user, err := Query[QueryMockUser](op.Select().From("users")).GetOne(context.Background(), query)
+			require.Nil(t, user)
+			require.ErrorIs(t, err, ErrNotFound)
+			require.ErrorIs(t, err, sql.ErrNoRows)
+			require.EqualError(t, err, "not found: sql: no rows in result set")
+
+			user, err = Query[QueryMockUser](op.Select().From("users")).GetOneOrNil(context.Background(), query)
+			require.NoError(t, err)
+			require.Nil(t, user)
+		})
+	}
+}
+
+func TestGetOneOrNil(t *testing.T) {
+	t.Parallel()
+	query := testutil.NewMockQueryable()
+	query.
+		On("QueryRow", mock.Anything, `SELECT "users"."id","users"."name" FROM "users" WHERE "users"."id" = ? LIMIT ?`, []any{1, uint64(1)}).
+		Return(testutil.NewMockRow(nil, []any{1, "Alex"}))
+	query.
+		On("QueryRow", mock.Anything, `SELECT "users"."id","users"."name" FROM "users" WHERE "users"."id" = ? LIMIT ?`, []any{2, uint64(1)}).
+		Return(testutil.NewMockRow(errors.New("sql syntax error"), nil))
+
+	user, err := Query[QueryMockUser](op.Select().From("users").Where(op.Eq("users.id", 1))).
+		GetOneOrNil(context.Background(), query)
+	require.NoError(t, err)
+	require.Equal(t, &QueryMockUser{ID: 1, Name: "Alex"}, user)
+
+	user, err = Query[QueryMockUser](op.Select().From("users").Where(op.Eq("users.id", 2))).
+		GetOneOrNil(context.Background(), query)
+	require.Nil(t, user)
+	require.EqualError(t, err, "sql syntax error")
+}
+
 func TestGetOneSqlError(t *testing.T) {
 	t.Parallel()
 	query := testutil.NewMockQueryable()
@@ -113,3 +166,151 @@ func TestGetOneModelError(t *testing.T) {
 		`"undefined": target is not described in the struct *orm.QueryMockUser`,
 	)
 }
+
+func TestGetManyCte(t *testing.T) {
+	t.Parallel()
+	expectedSql := `WITH "tree" AS (SELECT "id","name" FROM "users" WHERE "id" > ?) SELECT "tree"."id","tree"."name" FROM "tree"`
+	expectedArgs := []any{10}
+
+	query := testutil.NewMockQueryable()
+	query.
+		On("Query", mock.Anything, expectedSql, expectedArgs).
+		Return(testutil.NewMockRows(nil, []db.Scanner{
+			testutil.NewMockRow(nil, []any{11, "Alex"}),
+		}), nil)
+
+	users, err := Query[QueryMockUser](
+		op.Select().
+			Cte("tree", op.Select("id", "name").From("users").Where(op.Gt("id", 10))).
+			From("tree"),
+	).GetMany(context.Background(), query)
+
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	require.Equal(t, 11, users[0].ID)
+	require.Equal(t, "Alex", users[0].Name)
+}
+
+func TestGetManyCompound(t *testing.T) {
+	t.Parallel()
+	expectedSql := `SELECT "users"."id","users"."name" FROM (SELECT "id","name" FROM "users" WHERE "id" = ? UNION ALL SELECT "id","name" FROM "archived_users" WHERE "id" = ?) AS "users" ORDER BY "id" ASC`
+	expectedArgs := []any{1, 2}
+
+	query := testutil.NewMockQueryable()
+	query.
+		On("Query", mock.Anything, expectedSql, expectedArgs).
+		Return(testutil.NewMockRows(nil, []db.Scanner{
+			testutil.NewMockRow(nil, []any{1, "Alex"}),
+			testutil.NewMockRow(nil, []any{2, "John"}),
+		}), nil)
+
+	users, err := Query[QueryMockUser](
+		op.UnionAll(
+			op.Select("id", "name").From("users").Where(op.Eq("id", 1)),
+			op.Select("id", "name").From("archived_users").Where(op.Eq("id", 2)),
+		).OrderBy(op.Asc("id")),
+	).GetMany(context.Background(), query)
+
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+	require.Equal(t, 1, users[0].ID)
+	require.Equal(t, "John", users[1].Name)
+}
+
+func TestGetManyWindow(t *testing.T) {
+	t.Parallel()
+	type rankedUser struct {
+		ID   int `op:"id,primary"`
+		Rank int `op:"rank,aggregated"`
+	}
+
+	expectedSql := `SELECT "users"."id",(ROW_NUMBER() OVER "w") AS "rank" FROM "users" WINDOW "w" AS (PARTITION BY "company_id" ORDER BY "id" ASC)`
+	query := testutil.NewMockQueryable()
+	query.
+		On("Query", mock.Anything, expectedSql, []any(nil)).
+		Return(testutil.NewMockRows(nil, []db.Scanner{
+			testutil.NewMockRow(nil, []any{1, 1}),
+			testutil.NewMockRow(nil, []any{2, 2}),
+		}), nil)
+
+	users, err := Query[rankedUser](
+		op.Select("id", op.As("rank", op.Over(op.RowNumber(), "w"))).
+			From("users").
+			Window("w", op.Window().PartitionBy("company_id").OrderBy(op.Asc("id"))),
+	).GetMany(context.Background(), query)
+
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+	require.Equal(t, 2, users[1].ID)
+	require.Equal(t, 2, users[1].Rank)
+}
+
+func TestGetManyRollup(t *testing.T) {
+	t.Parallel()
+	type salesTotal struct {
+		Region  sql.NullString `op:"region,aggregated"`
+		IsTotal int            `op:"is_total,aggregated"`
+		Total   int            `op:"total,aggregated"`
+	}
+
+	expectedSql := `SELECT ("region") AS "region",(GROUPING("region")) AS "is_total",(SUM("amount")) AS "total" FROM "sales" GROUP BY ROLLUP ("region")`
+	query := testutil.NewMockQueryable()
+	query.
+		On("Query", mock.Anything, expectedSql, []any(nil)).
+		Return(testutil.NewMockRows(nil, []db.Scanner{
+			testutil.NewMockRow(nil, []any{sql.NullString{String: "EU", Valid: true}, 0, 10}),
+			testutil.NewMockRow(nil, []any{sql.NullString{}, 1, 30}),
+		}), nil)
+
+	totals, err := Query[salesTotal](
+		op.Select(
+			op.As("region", op.Column("region")),
+			op.As("is_total", op.Grouping("region")),
+			op.As("total", op.Sum("amount")),
+		).
+			From("sales").
+			GroupBy(op.Rollup("region")),
+	).GetMany(context.Background(), query)
+
+	require.NoError(t, err)
+	require.Len(t, totals, 2)
+	require.Equal(t, "EU", totals[0].Region.String)
+	require.Equal(t, 0, totals[0].IsTotal)
+	require.False(t, totals[1].Region.Valid)
+	require.Equal(t, 1, totals[1].IsTotal)
+	require.Equal(t, 30, totals[1].Total)
+}
+
+func TestGetManyUpdateJoin(t *testing.T) {
+	t.Parallel()
+	type company struct {
+		ID   int    `op:"id,primary"`
+		Name string `op:"name"`
+	}
+
+	type user struct {
+		ID      int      `op:"id,primary"`
+		Company *company `op:"companies,nested"`
+	}
+
+	expectedSql := `UPDATE "users" SET "company_name"="companies"."name" FROM "companies" WHERE ("users"."company_id" = "companies"."id" AND "companies"."id" = ?) RETURNING "users"."id","companies"."id","companies"."name"`
+	expectedArgs := []any{10}
+
+	query := testutil.NewMockQueryable()
+	query.
+		On("Query", mock.Anything, expectedSql, expectedArgs).
+		Return(testutil.NewMockRows(nil, []db.Scanner{
+			testutil.NewMockRow(nil, []any{1, 10, "Google"}),
+		}), nil)
+
+	users, err := Query[user](
+		op.Update("users", op.Updates{"company_name": op.Column("companies.name")}).
+			Join("companies", op.Eq("users.company_id", op.Column("companies.id"))).
+			Where(op.Eq("companies.id", 10)),
+	).GetMany(context.Background(), query)
+
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	require.Equal(t, 1, users[0].ID)
+	require.Equal(t, "Google", users[0].Company.Name)
+}
@@ -1,6 +1,10 @@
 package op
 
-import "github.com/xsqrty/op/driver"
+import (
+	"strings"
+
+	"github.com/xsqrty/op/driver"
+)
 
 // CounterType represents a type used to differentiate execution counters, such as CounterQuery and CounterExec.
 type CounterType uint8
@@ -23,3 +27,44 @@ type Returnable interface {
 	LimitReturningOne()
 	CounterType() CounterType
 }
+
+// outputSql generates the SQL Server OUTPUT clause, which replaces RETURNING, such as `OUTPUT INSERTED.[id]`.
+// Columns are referenced by their names in the pseudo table (INSERTED or DELETED), expressions are rendered as is.
+func outputSql(pseudoTable string, keys []Alias, options *driver.SqlOptions) (string, []any, error) {
+	var buf strings.Builder
+	var args []any
+
+	buf.WriteString(" OUTPUT ")
+	for i := range keys {
+		if i > 0 {
+			buf.WriteByte(options.FieldsDelim)
+		}
+
+		if keys[i].IsPureColumn() {
+			name := keys[i].Alias()
+			if pos := strings.LastIndexByte(name, delimByte); pos != -1 {
+				name = name[pos+1:]
+			}
+
+			sql, _, err := Column(name).Sql(options)
+			if err != nil {
+				return "", nil, err
+			}
+
+			buf.WriteString(pseudoTable)
+			buf.WriteByte(delimByte)
+			buf.WriteString(sql)
+			continue
+		}
+
+		sql, keyArgs, err := keys[i].Sql(options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		args = append(args, keyArgs...)
+		buf.WriteString(sql)
+	}
+
+	return buf.String(), args, nil
+}
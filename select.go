@@ -2,6 +2,7 @@ package op
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/xsqrty/op/driver"
@@ -9,6 +10,10 @@ import (
 
 // SelectBuilder provides an interface for building SQL SELECT queries with methods for setting clauses and options.
 type SelectBuilder interface {
+	// Cte adds a named common table expression to the WITH clause, optionally with the list of its columns.
+	Cte(name string, query driver.Sqler, columns ...string) SelectBuilder
+	// CteRecursive adds a named common table expression to the WITH clause and marks the clause as RECURSIVE.
+	CteRecursive(name string, query driver.Sqler, columns ...string) SelectBuilder
 	// Distinct adds a DISTINCT clause to the query, optionally for the specified columns or expressions.
 	Distinct(args ...string) SelectBuilder
 	// All resets the SELECT clause of the query to include all columns (*) in the result set.
@@ -27,8 +32,17 @@ type SelectBuilder interface {
 	RightJoin(table any, on driver.Sqler) SelectBuilder
 	// InnerJoin adds an INNER JOIN clause to the query with the specified table and ON condition.
 	InnerJoin(table any, on driver.Sqler) SelectBuilder
-	// CrossJoin adds a CROSS JOIN clause to the query with the specified table and join condition.
-	CrossJoin(table any, on driver.Sqler) SelectBuilder
+	// FullJoin adds a FULL OUTER JOIN clause to the query with the specified table and ON condition.
+	FullJoin(table any, on driver.Sqler) SelectBuilder
+	// CrossJoin adds a CROSS JOIN clause to the query with the specified table, which has no join condition.
+	// The optional condition is kept for compatibility and rendered as the ON clause, use Join for the joins with a condition.
+	CrossJoin(table any, on ...driver.Sqler) SelectBuilder
+	// JoinLateral adds a JOIN LATERAL clause to the query with the specified subquery and ON condition.
+	JoinLateral(table any, on driver.Sqler) SelectBuilder
+	// LeftJoinLateral adds a LEFT JOIN LATERAL clause to the query with the specified subquery and ON condition.
+	LeftJoinLateral(table any, on driver.Sqler) SelectBuilder
+	// CrossJoinLateral adds a CROSS JOIN LATERAL clause to the query with the specified subquery.
+	CrossJoinLateral(table any) SelectBuilder
 	// Limit sets the maximum number of rows to return in the SQL SELECT statement. It accepts a positive integer value.
 	Limit(limit uint64) SelectBuilder
 	// Offset sets the OFFSET clause for the SQL query to skip a specified number of rows and returns the updated SelectBuilder.
@@ -37,6 +51,20 @@ type SelectBuilder interface {
 	GroupBy(groups ...any) SelectBuilder
 	// OrderBy adds one or more ordering criteria to the SELECT query, specifying the sort order of the result set.
 	OrderBy(orders ...Order) SelectBuilder
+	// Window adds a named window definition to the WINDOW clause, which can be referenced by the OVER clause.
+	Window(name string, window WindowBuilder) SelectBuilder
+	// ForUpdate adds a FOR UPDATE row locking clause, optionally restricted to the specified tables.
+	ForUpdate(of ...string) SelectBuilder
+	// ForNoKeyUpdate adds a FOR NO KEY UPDATE row locking clause, optionally restricted to the specified tables.
+	ForNoKeyUpdate(of ...string) SelectBuilder
+	// ForShare adds a FOR SHARE row locking clause, optionally restricted to the specified tables.
+	ForShare(of ...string) SelectBuilder
+	// ForKeyShare adds a FOR KEY SHARE row locking clause, optionally restricted to the specified tables.
+	ForKeyShare(of ...string) SelectBuilder
+	// NoWait makes the last row locking clause report an error instead of waiting for the locked rows.
+	NoWait() SelectBuilder
+	// SkipLocked makes the last row locking clause skip the rows that cannot be locked immediately.
+	SkipLocked() SelectBuilder
 	// LimitReturningOne sets the SELECT statement to return only one row
 	LimitReturningOne()
 	// With returns the name of the table used in the current SELECT statement.
@@ -84,10 +112,17 @@ type order struct {
 }
 
 // join represents a SQL join operation with associated table, join type, and ON clause for join conditions.
+// The lateral flag allows the joined subquery to reference columns of the preceding tables.
 type join struct {
 	table    Alias
 	joinType joinType
 	on       driver.Sqler
+	lateral  bool
+}
+
+// using represents the USING clause of a join, which joins tables by the columns with the same names.
+type using struct {
+	columns []Column
 }
 
 // distinctOn represents an internal structure to handle DISTINCT ON clauses with specific columns in SQL queries.
@@ -118,18 +153,21 @@ const (
 // joinRight represents a RIGHT JOIN in SQL context.
 // joinInner represents an INNER JOIN in SQL context.
 // joinCross represents a CROSS JOIN in SQL context.
+// joinFull represents a FULL OUTER JOIN in SQL context.
 const (
 	joinDefault joinType = iota
 	joinLeft
 	joinRight
 	joinInner
 	joinCross
+	joinFull
 )
 
 // selectBuilder is a structure for constructing SQL SELECT queries programmatically.
 // It includes fields for managing query components such as FROM, WHERE, HAVING, JOIN, GROUP BY, ORDER BY, and limits.
 // The fields allow fine-grained customization of the query logic and structure.
 type selectBuilder struct {
+	ctes    ctes
 	from    Alias
 	where   And
 	having  And
@@ -137,6 +175,8 @@ type selectBuilder struct {
 	fields  []Alias
 	orders  []Order
 	groupBy []driver.Sqler
+	windows []namedWindow
+	locks   []lock
 	fp      driver.Sqler
 	err     error
 	limit   uint64
@@ -154,6 +194,18 @@ func Select(fields ...any) SelectBuilder {
 	return sb
 }
 
+// Cte adds a common table expression with the given name, query, and optional columns to the WITH clause.
+func (sb *selectBuilder) Cte(name string, query driver.Sqler, columns ...string) SelectBuilder {
+	sb.ctes.add(name, query, columns, false)
+	return sb
+}
+
+// CteRecursive adds a common table expression to the WITH clause and renders the clause as WITH RECURSIVE.
+func (sb *selectBuilder) CteRecursive(name string, query driver.Sqler, columns ...string) SelectBuilder {
+	sb.ctes.add(name, query, columns, true)
+	return sb
+}
+
 // Distinct applies the DISTINCT clause to the query. Accepts optional column names for "DISTINCT ON" functionality.
 func (sb *selectBuilder) Distinct(args ...string) SelectBuilder {
 	if len(args) == 0 {
@@ -238,9 +290,49 @@ func (sb *selectBuilder) InnerJoin(table any, on driver.Sqler) SelectBuilder {
 	return sb
 }
 
-// CrossJoin adds a CROSS JOIN clause to the SQL query with the specified table and condition.
-func (sb *selectBuilder) CrossJoin(table any, on driver.Sqler) SelectBuilder {
-	sb.joins = append(sb.joins, join{table: sb.parseJoinTable(table), on: on, joinType: joinCross})
+// FullJoin adds a FULL OUTER JOIN clause to the query with the specified table and ON condition.
+func (sb *selectBuilder) FullJoin(table any, on driver.Sqler) SelectBuilder {
+	sb.joins = append(sb.joins, join{table: sb.parseJoinTable(table), on: on, joinType: joinFull})
+	return sb
+}
+
+// CrossJoin adds a CROSS JOIN clause to the SQL query with the specified table. Cross joins have no join condition.
+// The non-nil conditions, accepted for compatibility with the previous signature, are combined using AND into the ON clause.
+func (sb *selectBuilder) CrossJoin(table any, on ...driver.Sqler) SelectBuilder {
+	var cond And
+	for _, exp := range on {
+		if exp != nil {
+			cond = append(cond, exp)
+		}
+	}
+
+	j := join{table: sb.parseJoinTable(table), joinType: joinCross}
+	if len(cond) > 0 {
+		j.on = cond
+	}
+
+	sb.joins = append(sb.joins, j)
+	return sb
+}
+
+// JoinLateral adds a JOIN LATERAL clause with the specified subquery and ON condition.
+// The subquery can reference columns of the tables preceding it in the FROM clause.
+func (sb *selectBuilder) JoinLateral(table any, on driver.Sqler) SelectBuilder {
+	sb.joins = append(sb.joins, join{table: sb.parseJoinTable(table), on: on, joinType: joinDefault, lateral: true})
+	return sb
+}
+
+// LeftJoinLateral adds a LEFT JOIN LATERAL clause with the specified subquery and ON condition.
+// The subquery can reference columns of the tables preceding it in the FROM clause.
+func (sb *selectBuilder) LeftJoinLateral(table any, on driver.Sqler) SelectBuilder {
+	sb.joins = append(sb.joins, join{table: sb.parseJoinTable(table), on: on, joinType: joinLeft, lateral: true})
+	return sb
+}
+
+// CrossJoinLateral adds a CROSS JOIN LATERAL clause with the specified subquery, which has no join condition.
+// The subquery can reference columns of the tables preceding it in the FROM clause.
+func (sb *selectBuilder) CrossJoinLateral(table any) SelectBuilder {
+	sb.joins = append(sb.joins, join{table: sb.parseJoinTable(table), joinType: joinCross, lateral: true})
 	return sb
 }
 
@@ -278,8 +370,50 @@ func (sb *selectBuilder) OrderBy(orders ...Order) SelectBuilder {
 	return sb
 }
 
+// Window appends a named window definition to the WINDOW clause and returns the updated SelectBuilder instance.
+func (sb *selectBuilder) Window(name string, window WindowBuilder) SelectBuilder {
+	sb.windows = append(sb.windows, namedWindow{name: Column(name), window: window})
+	return sb
+}
+
+// ForUpdate appends a FOR UPDATE row locking clause for the optional tables and returns the updated SelectBuilder instance.
+func (sb *selectBuilder) ForUpdate(of ...string) SelectBuilder {
+	sb.locks = append(sb.locks, newLock(lockUpdate, of))
+	return sb
+}
+
+// ForNoKeyUpdate appends a FOR NO KEY UPDATE row locking clause for the optional tables and returns the updated SelectBuilder instance.
+func (sb *selectBuilder) ForNoKeyUpdate(of ...string) SelectBuilder {
+	sb.locks = append(sb.locks, newLock(lockNoKeyUpdate, of))
+	return sb
+}
+
+// ForShare appends a FOR SHARE row locking clause for the optional tables and returns the updated SelectBuilder instance.
+func (sb *selectBuilder) ForShare(of ...string) SelectBuilder {
+	sb.locks = append(sb.locks, newLock(lockShare, of))
+	return sb
+}
+
+// ForKeyShare appends a FOR KEY SHARE row locking clause for the optional tables and returns the updated SelectBuilder instance.
+func (sb *selectBuilder) ForKeyShare(of ...string) SelectBuilder {
+	sb.locks = append(sb.locks, newLock(lockKeyShare, of))
+	return sb
+}
+
+// NoWait sets the NOWAIT option of the last row locking clause. Sets an error if no locking clause was added.
+func (sb *selectBuilder) NoWait() SelectBuilder {
+	sb.setLockWait(lockNoWait)
+	return sb
+}
+
+// SkipLocked sets the SKIP LOCKED option of the last row locking clause. Sets an error if no locking clause was added.
+func (sb *selectBuilder) SkipLocked() SelectBuilder {
+	sb.setLockWait(lockSkipLocked)
+	return sb
+}
+
 // Sql generates an SQL query string along with its arguments and any encountered error.
-// It assembles the SELECT statement with fields, tables, joins, conditions, groups, orders, limits, and offsets.
+// It assembles the SELECT statement with common table expressions, fields, tables, joins, conditions, groups, windows, orders, limits, offsets, and locks.
 func (sb *selectBuilder) Sql(options *driver.SqlOptions) (sql string, args []any, err error) {
 	if sb.err != nil {
 		err = sb.err
@@ -287,6 +421,17 @@ func (sb *selectBuilder) Sql(options *driver.SqlOptions) (sql string, args []any
 	}
 
 	var buf strings.Builder
+	if len(sb.ctes.items) > 0 {
+		sql, ctesArgs, err := sb.ctes.Sql(options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		args = append(args, ctesArgs...)
+		buf.WriteString(sql)
+		buf.WriteByte(' ')
+	}
+
 	buf.WriteString("SELECT")
 	if sb.fp != nil {
 		sql, fpArgs, err := sb.fp.Sql(options)
@@ -299,6 +444,13 @@ func (sb *selectBuilder) Sql(options *driver.SqlOptions) (sql string, args []any
 		args = append(args, fpArgs...)
 	}
 
+	// SQL Server has no LIMIT, the limit without offset is rendered as "TOP n"
+	top := options.Dialect == driver.DialectMssql && sb.limit > 0 && sb.offset == 0
+	if top {
+		buf.WriteString(" TOP ")
+		buf.WriteString(strconv.FormatUint(sb.limit, 10))
+	}
+
 	if len(sb.fields) > 0 {
 		sql, fieldsArgs, err := concatFields(sb.fields, options)
 		if err != nil {
@@ -325,29 +477,13 @@ func (sb *selectBuilder) Sql(options *driver.SqlOptions) (sql string, args []any
 
 	if len(sb.joins) > 0 {
 		for i := range sb.joins {
-			buf.WriteString(" " + sb.joins[i].joinType.String() + " ")
-			sql, tableArgs, err := sb.joins[i].table.Sql(options)
+			sql, joinArgs, err := sb.joins[i].Sql(options)
 			if err != nil {
 				return "", nil, err
 			}
 
-			args = append(args, tableArgs...)
-			buf.WriteString(sql)
-
-			if sb.joins[i].on == nil {
-				return "", nil, fmt.Errorf(
-					"%s operation requires an ON clause to specify join condition",
-					sb.joins[i].joinType,
-				)
-			}
-
-			buf.WriteString(" ON ")
-			sql, onArgs, err := sb.joins[i].on.Sql(options)
-			if err != nil {
-				return "", nil, err
-			}
-
-			args = append(args, onArgs...)
+			args = append(args, joinArgs...)
+			buf.WriteByte(' ')
 			buf.WriteString(sql)
 		}
 	}
@@ -385,34 +521,42 @@ func (sb *selectBuilder) Sql(options *driver.SqlOptions) (sql string, args []any
 		buf.WriteString(sql)
 	}
 
-	if len(sb.orders) > 0 {
-		buf.WriteString(" ORDER BY ")
-		sql, ordersArgs, err := concatFields[Order](sb.orders, options)
+	if len(sb.windows) > 0 {
+		buf.WriteString(" WINDOW ")
+		sql, windowsArgs, err := concatFields(sb.windows, options)
 		if err != nil {
 			return "", nil, err
 		}
 
-		args = append(args, ordersArgs...)
+		args = append(args, windowsArgs...)
 		buf.WriteString(sql)
 	}
 
-	if sb.limit > 0 {
-		sql, limitArgs, err := driver.Pure(" LIMIT ?", sb.limit).Sql(options)
+	if len(sb.orders) > 0 {
+		buf.WriteString(" ORDER BY ")
+		sql, ordersArgs, err := concatFields[Order](sb.orders, options)
 		if err != nil {
 			return "", nil, err
 		}
 
-		args = append(args, limitArgs...)
+		args = append(args, ordersArgs...)
 		buf.WriteString(sql)
 	}
 
-	if sb.offset > 0 {
-		sql, offsetArgs, err := driver.Pure(" OFFSET ?", sb.offset).Sql(options)
+	if !top {
+		sql, pagingArgs := pagingSql(sb.limit, sb.offset, len(sb.orders) > 0, options)
+		args = append(args, pagingArgs...)
+		buf.WriteString(sql)
+	}
+
+	for i := range sb.locks {
+		sql, lockArgs, err := sb.locks[i].Sql(options)
 		if err != nil {
 			return "", nil, err
 		}
 
-		args = append(args, offsetArgs...)
+		args = append(args, lockArgs...)
+		buf.WriteByte(' ')
 		buf.WriteString(sql)
 	}
 
@@ -481,6 +625,16 @@ func (sb *selectBuilder) setFields(fields []any) error {
 	return nil
 }
 
+// setLockWait sets the waiting behavior of the last row locking clause, or sets an error if there is no locking clause.
+func (sb *selectBuilder) setLockWait(wait lockWaitType) {
+	if len(sb.locks) == 0 {
+		sb.err = fmt.Errorf("%s requires a row locking clause", wait)
+		return
+	}
+
+	sb.locks[len(sb.locks)-1].wait = wait
+}
+
 // parseJoinTable handles the parsing of a table input, validating and converting it into an Alias or setting an error.
 func (sb *selectBuilder) parseJoinTable(table any) Alias {
 	switch val := table.(type) {
@@ -494,6 +648,81 @@ func (sb *selectBuilder) parseJoinTable(table any) Alias {
 	}
 }
 
+// Using creates a USING clause to be passed as a join condition, which joins tables by the columns with the same names.
+// It renders as `USING ("column",...)` instead of an ON clause.
+func Using(columns ...string) driver.Sqler {
+	u := &using{columns: make([]Column, len(columns))}
+	for i := range columns {
+		u.columns[i] = Column(columns[i])
+	}
+
+	return u
+}
+
+// Sql generates the USING clause with the list of the join columns.
+func (u *using) Sql(options *driver.SqlOptions) (string, []any, error) {
+	if options.Dialect == driver.DialectMssql {
+		return "", nil, fmt.Errorf("join using: %w %q", ErrUnsupportedDialect, options.Dialect)
+	}
+
+	if len(u.columns) == 0 {
+		return "", nil, fmt.Errorf("USING clause: %w", ErrFieldsEmpty)
+	}
+
+	sql, args, err := concatFields(u.columns, options)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return "USING (" + sql + ")", args, nil
+}
+
+// Sql generates the join clause, such as `LEFT JOIN "roles" ON ...` or `JOIN "roles" USING (...)`.
+// Cross joins are rendered without a join condition, other joins require an ON or USING clause.
+func (j *join) Sql(options *driver.SqlOptions) (string, []any, error) {
+	if j.joinType == joinFull && options.Dialect == driver.DialectMysql {
+		return "", nil, fmt.Errorf("full join: %w %q", ErrUnsupportedDialect, options.Dialect)
+	}
+
+	var buf strings.Builder
+	buf.WriteString(j.joinType.String())
+	if j.lateral {
+		if options.Dialect == driver.DialectSqlite || options.Dialect == driver.DialectMssql {
+			return "", nil, fmt.Errorf("lateral join: %w %q", ErrUnsupportedDialect, options.Dialect)
+		}
+
+		buf.WriteString(" LATERAL")
+	}
+
+	buf.WriteByte(' ')
+	sql, args, err := j.table.Sql(options)
+	if err != nil {
+		return "", nil, err
+	}
+
+	buf.WriteString(sql)
+	if j.joinType == joinCross && j.on == nil {
+		return buf.String(), args, nil
+	}
+
+	if j.on == nil {
+		return "", nil, fmt.Errorf("%s operation requires an ON clause to specify join condition", j.joinType)
+	}
+
+	if _, ok := j.on.(*using); !ok {
+		buf.WriteString(" ON")
+	}
+
+	buf.WriteByte(' ')
+	sql, onArgs, err := j.on.Sql(options)
+	if err != nil {
+		return "", nil, err
+	}
+
+	buf.WriteString(sql)
+	return buf.String(), append(args, onArgs...), nil
+}
+
 // Desc creates a descending order instance for the specified column, determining SQL sorting logic.
 func Desc(column any) Order {
 	return &order{
@@ -626,7 +855,51 @@ func (j joinType) String() string {
 		return "INNER JOIN"
 	case joinCross:
 		return "CROSS JOIN"
+	case joinFull:
+		return "FULL OUTER JOIN"
 	}
 
 	return "JOIN"
 }
+
+// pagingSql generates the LIMIT and OFFSET clauses of the query, such as " LIMIT ? OFFSET ?".
+// SQL Server renders them as "OFFSET ? ROWS FETCH NEXT ? ROWS ONLY", which requires the ORDER BY clause,
+// so "ORDER BY (SELECT NULL)" is added if the query is not ordered. MySQL requires LIMIT before OFFSET,
+// so the maximum number of rows is used as the limit if only the offset is set.
+func pagingSql(limit uint64, offset uint64, ordered bool, options *driver.SqlOptions) (string, []any) {
+	if limit == 0 && offset == 0 {
+		return "", nil
+	}
+
+	var buf strings.Builder
+	var args []any
+
+	if options.Dialect == driver.DialectMssql {
+		if !ordered {
+			buf.WriteString(" ORDER BY (SELECT NULL)")
+		}
+
+		buf.WriteString(" OFFSET ? ROWS")
+		args = append(args, offset)
+		if limit > 0 {
+			buf.WriteString(" FETCH NEXT ? ROWS ONLY")
+			args = append(args, limit)
+		}
+
+		return buf.String(), args
+	}
+
+	if limit > 0 {
+		buf.WriteString(" LIMIT ?")
+		args = append(args, limit)
+	} else if options.Dialect == driver.DialectMysql {
+		buf.WriteString(" LIMIT 18446744073709551615")
+	}
+
+	if offset > 0 {
+		buf.WriteString(" OFFSET ?")
+		args = append(args, offset)
+	}
+
+	return buf.String(), args
+}
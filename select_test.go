@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"github.com/xsqrty/op/driver"
 	"github.com/xsqrty/op/internal/testutil"
 )
 
@@ -86,6 +87,71 @@ func TestSelect(t *testing.T) {
 			ExpectedSql:  `SELECT "id","name" FROM "users" CROSS JOIN "roles" ON "user_id" = "users"."id"`,
 			ExpectedArgs: []any(nil),
 		},
+		{
+			Name: "cross_join_without_on",
+			Builder: Select(
+				"id",
+				"name",
+			).From("users").
+				CrossJoin("roles").
+				CrossJoin("companies", nil),
+			ExpectedSql:  `SELECT "id","name" FROM "users" CROSS JOIN "roles" CROSS JOIN "companies"`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name: "full_join",
+			Builder: Select(
+				"id",
+				"name",
+			).From("users").
+				FullJoin("roles", Eq("user_id", Column("users.id"))),
+			ExpectedSql:  `SELECT "id","name" FROM "users" FULL OUTER JOIN "roles" ON "user_id" = "users"."id"`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "join_using",
+			Builder:      Select("id", "name").From("users").Join("profiles", Using("id", "tenant_id")),
+			ExpectedSql:  `SELECT "id","name" FROM "users" JOIN "profiles" USING ("id","tenant_id")`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "left_join_using",
+			Builder:      Select("id").From("users").LeftJoin(ColumnAlias("profiles"), Using("id")).Where(Eq("name", "Alex")),
+			ExpectedSql:  `SELECT "id" FROM "users" LEFT JOIN "profiles" USING ("id") WHERE "name" = ?`,
+			ExpectedArgs: []any{"Alex"},
+		},
+		{
+			Name: "join_lateral",
+			Builder: Select("users.name", "orders.total").From("users").JoinLateral(
+				As("orders", Select("total").From("orders").Where(And{
+					Eq("orders.user_id", Column("users.id")),
+					Gt("orders.total", 100),
+				}).OrderBy(Desc("total")).Limit(3)),
+				Eq("orders.total", Column("users.max_total")),
+			),
+			ExpectedSql:  `SELECT "users"."name","orders"."total" FROM "users" JOIN LATERAL (SELECT "total" FROM "orders" WHERE ("orders"."user_id" = "users"."id" AND "orders"."total" > ?) ORDER BY "total" DESC LIMIT ?) AS "orders" ON "orders"."total" = "users"."max_total"`,
+			ExpectedArgs: []any{100, uint64(3)},
+			SqlOptions:   testutil.NewDialectOptions(driver.DialectPostgres),
+		},
+		{
+			Name: "left_join_lateral",
+			Builder: Select("users.name", "orders.total").From("users").LeftJoinLateral(
+				As("orders", Select("total").From("orders").Where(Eq("orders.user_id", Column("users.id"))).Limit(1)),
+				Eq(driver.Value(true), true),
+			),
+			ExpectedSql:  `SELECT "users"."name","orders"."total" FROM "users" LEFT JOIN LATERAL (SELECT "total" FROM "orders" WHERE "orders"."user_id" = "users"."id" LIMIT ?) AS "orders" ON ? = ?`,
+			ExpectedArgs: []any{uint64(1), true, true},
+			SqlOptions:   testutil.NewDialectOptions(driver.DialectPostgres),
+		},
+		{
+			Name: "cross_join_lateral",
+			Builder: Select("users.name", "orders.total").From("users").CrossJoinLateral(
+				As("orders", Select("total").From("orders").Where(Eq("orders.user_id", Column("users.id"))).Limit(2)),
+			).Where(Eq("users.active", true)),
+			ExpectedSql:  `SELECT "users"."name","orders"."total" FROM "users" CROSS JOIN LATERAL (SELECT "total" FROM "orders" WHERE "orders"."user_id" = "users"."id" LIMIT ?) AS "orders" WHERE "users"."active" = ?`,
+			ExpectedArgs: []any{uint64(2), true},
+			SqlOptions:   testutil.NewDialectOptions(driver.DialectPostgres),
+		},
 		{
 			Name:         "where",
 			Builder:      Select("id", "name").From("users").Where(Or{Eq("id", 1), Eq("id", 2)}),
@@ -135,6 +201,13 @@ func TestSelect(t *testing.T) {
 			ExpectedSql:  `SELECT "id","name" FROM "users" OFFSET ?`,
 			ExpectedArgs: []any{uint64(10)},
 		},
+		{
+			Name:         "offset_mysql",
+			Builder:      Select("id", "name").From("users").Offset(10),
+			SqlOptions:   testutil.NewDialectOptions(driver.DialectMysql),
+			ExpectedSql:  `SELECT "id","name" FROM "users" LIMIT 18446744073709551615 OFFSET ?`,
+			ExpectedArgs: []any{uint64(10)},
+		},
 		{
 			Name:         "distinct",
 			Builder:      Select("id", "name").From("users").Distinct(),
@@ -173,6 +246,21 @@ func TestSelect(t *testing.T) {
 			ExpectedArgs: []any(nil),
 			ExpectedErr:  "JOIN operation requires an ON clause to specify join condition",
 		},
+		{
+			Name:         "error_join_using",
+			Builder:      Select("id").From("users").Join("profiles", Using()),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  "USING clause: fields is empty",
+		},
+		{
+			Name:         "error_join_lateral",
+			Builder:      Select("id").From("users").CrossJoinLateral(As("orders", Select().From("orders"))),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `lateral join: unsupported in dialect "sqlite"`,
+			SqlOptions:   testutil.NewDialectOptions(driver.DialectSqlite),
+		},
 		{
 			Name:         "error_join_3",
 			Builder:      Select("id", "name").From("users").Join(10, nil),
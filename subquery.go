@@ -0,0 +1,62 @@
+package op
+
+import (
+	"fmt"
+
+	"github.com/xsqrty/op/driver"
+)
+
+// subquery represents a query wrapped in parentheses with an optional prefix, such as EXISTS.
+type subquery struct {
+	prefix string
+	query  driver.Sqler
+}
+
+// Exists creates an "EXISTS (subquery)" predicate, which is true if the subquery returns at least one row.
+func Exists(query driver.Sqler) driver.Sqler {
+	return &subquery{prefix: "EXISTS ", query: query}
+}
+
+// NotExists creates a "NOT EXISTS (subquery)" predicate, which is true if the subquery returns no rows.
+func NotExists(query driver.Sqler) driver.Sqler {
+	return &subquery{prefix: "NOT EXISTS ", query: query}
+}
+
+// Subquery wraps the query in parentheses, making it a scalar subquery usable as a value of expressions.
+// Use As to select the scalar subquery as a field.
+func Subquery(query driver.Sqler) driver.Sqler {
+	return &subquery{query: query}
+}
+
+// Sql generates the subquery wrapped in parentheses, such as `EXISTS (SELECT ...)`.
+func (s *subquery) Sql(options *driver.SqlOptions) (string, []any, error) {
+	if s.query == nil {
+		return "", nil, fmt.Errorf("%ssubquery can't be nil", s.prefix)
+	}
+
+	sql, args, err := s.query.Sql(options)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return s.prefix + "(" + sql + ")", args, nil
+}
+
+// isSubquery reports whether the value is a query, which must be wrapped in parentheses when used as an expression.
+func isSubquery(v any) bool {
+	switch v.(type) {
+	case SelectBuilder, CompoundBuilder:
+		return true
+	}
+
+	return false
+}
+
+// wrapSubquery wraps the value in parentheses if it is a query, otherwise returns the value as is.
+func wrapSubquery(v any) any {
+	if isSubquery(v) {
+		return Subquery(v.(driver.Sqler))
+	}
+
+	return v
+}
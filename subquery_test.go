@@ -0,0 +1,124 @@
+package op
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xsqrty/op/driver"
+	"github.com/xsqrty/op/internal/testutil"
+)
+
+func TestSubquery(t *testing.T) {
+	t.Parallel()
+	orders := func() SelectBuilder {
+		return Select().From("orders").Where(And{Eq("orders.user_id", Column("users.id")), Gt("orders.total", 100)})
+	}
+
+	testutil.RunCases(t, options, []testutil.TestCase{
+		{
+			Name:         "exists",
+			Builder:      Select("id").From("users").Where(Exists(orders())),
+			ExpectedSql:  `SELECT "id" FROM "users" WHERE EXISTS (SELECT * FROM "orders" WHERE ("orders"."user_id" = "users"."id" AND "orders"."total" > ?))`,
+			ExpectedArgs: []any{100},
+		},
+		{
+			Name:         "not_exists",
+			Builder:      Select("id").From("users").Where(NotExists(orders())),
+			ExpectedSql:  `SELECT "id" FROM "users" WHERE NOT EXISTS (SELECT * FROM "orders" WHERE ("orders"."user_id" = "users"."id" AND "orders"."total" > ?))`,
+			ExpectedArgs: []any{100},
+		},
+		{
+			Name:         "in_select",
+			Builder:      In("id", Select("user_id").From("orders").Where(Gt("total", 100))),
+			ExpectedSql:  `"id" IN (SELECT "user_id" FROM "orders" WHERE "total" > ?)`,
+			ExpectedArgs: []any{100},
+		},
+		{
+			Name:         "not_in_compound",
+			Builder:      Nin("id", Union(Select("id").From("admins"), Select("id").From("guests"))),
+			ExpectedSql:  `"id" NOT IN (SELECT "id" FROM "admins" UNION SELECT "id" FROM "guests")`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "in_scalar_subqueries",
+			Builder:      In("id", 1, Select(As("max_id", Max("id"))).From("users")),
+			ExpectedSql:  `"id" IN (?,(SELECT (MAX("id")) AS "max_id" FROM "users"))`,
+			ExpectedArgs: []any{1},
+		},
+		{
+			Name:         "scalar_value",
+			Builder:      Gt("price", Select(As("avg_price", Avg("price"))).From("products").Where(Eq("category", "books"))),
+			ExpectedSql:  `"price" > (SELECT (AVG("price")) AS "avg_price" FROM "products" WHERE "category" = ?)`,
+			ExpectedArgs: []any{"books"},
+		},
+		{
+			Name:         "scalar_key",
+			Builder:      Lt(Select(As("total", Count("id"))).From("orders").Where(Eq("orders.user_id", Column("users.id"))), 5),
+			ExpectedSql:  `(SELECT (COUNT("id")) AS "total" FROM "orders" WHERE "orders"."user_id" = "users"."id") < ?`,
+			ExpectedArgs: []any{5},
+		},
+		{
+			Name: "scalar_field",
+			Builder: Select(
+				"id",
+				As("orders_count", Select(As("total", Count("id"))).From("orders").Where(Eq("orders.user_id", Column("users.id")))),
+			).From("users"),
+			ExpectedSql:  `SELECT "id",(SELECT (COUNT("id")) AS "total" FROM "orders" WHERE "orders"."user_id" = "users"."id") AS "orders_count" FROM "users"`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "subquery",
+			Builder:      Coalesce(Subquery(Select("name").From("users").Where(Eq("id", 1))), "login"),
+			ExpectedSql:  `COALESCE((SELECT "name" FROM "users" WHERE "id" = ?),"login")`,
+			ExpectedArgs: []any{1},
+		},
+		{
+			Name:         "error_nil",
+			Builder:      Exists(nil),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  "EXISTS subquery can't be nil",
+		},
+		{
+			Name:         "error_query",
+			Builder:      Subquery(Select("a+b")),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `target "a+b" contains illegal character '+'`,
+		},
+	})
+}
+
+func TestSubqueryPreparedSql(t *testing.T) {
+	t.Parallel()
+	options := testutil.NewDialectOptions(driver.DialectPostgres)
+	options.PlaceholderFormat = func(n int) string {
+		return "$" + strconv.Itoa(n)
+	}
+
+	sql, args, err := Select(
+		"id",
+		As("paid", Select(As("sum", Sum("total"))).From("orders").Where(And{
+			Eq("orders.user_id", Column("users.id")),
+			Eq("orders.status", "paid"),
+		})),
+	).
+		From("users").
+		Where(And{
+			Eq("active", true),
+			Exists(Select().From("orders").Where(Gt("orders.total", 100))),
+			In("company_id", Select("id").From("companies").Where(Eq("country", "NL"))),
+			Gt(Select(As("total", Count("id"))).From("logins").Where(Eq("logins.user_id", Column("users.id"))), 3),
+		}).
+		Limit(10).
+		PreparedSql(options)
+
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		`SELECT "id",(SELECT (SUM("total")) AS "sum" FROM "orders" WHERE ("orders"."user_id" = "users"."id" AND "orders"."status" = $1)) AS "paid" FROM "users" WHERE ("active" = $2 AND EXISTS (SELECT * FROM "orders" WHERE "orders"."total" > $3) AND "company_id" IN (SELECT "id" FROM "companies" WHERE "country" = $4) AND (SELECT (COUNT("id")) AS "total" FROM "logins" WHERE "logins"."user_id" = "users"."id") > $5) LIMIT $6`,
+		sql,
+	)
+	require.Equal(t, []any{"paid", true, 100, "NL", 3, uint64(10)}, args)
+}
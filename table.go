@@ -0,0 +1,587 @@
+package op
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xsqrty/op/driver"
+)
+
+// CreateTableBuilder provides an interface for building SQL CREATE TABLE statements.
+type CreateTableBuilder interface {
+	// IfNotExists adds the IF NOT EXISTS clause to the CREATE TABLE statement.
+	IfNotExists() CreateTableBuilder
+	// Columns adds the column definitions to the table.
+	Columns(columns ...ColumnDefBuilder) CreateTableBuilder
+	// Constraints adds the table constraints, such as PRIMARY KEY, UNIQUE, CHECK or FOREIGN KEY.
+	Constraints(constraints ...Constraint) CreateTableBuilder
+	// PreparedSql generates the CREATE TABLE statement, which never has placeholders, based on the provided SqlOptions.
+	PreparedSql(options *driver.SqlOptions) (string, []any, error)
+	// Sql generates the CREATE TABLE statement with inlined literals based on the provided SqlOptions.
+	Sql(options *driver.SqlOptions) (string, []any, error)
+}
+
+// AlterTableBuilder provides an interface for building SQL ALTER TABLE statements.
+// Sqlite supports only adding, dropping and renaming of the columns and renaming of the table.
+type AlterTableBuilder interface {
+	// AddColumn adds the ADD COLUMN action with the specified column definition.
+	AddColumn(column ColumnDefBuilder) AlterTableBuilder
+	// DropColumn adds the DROP COLUMN action for the specified column.
+	DropColumn(name string) AlterTableBuilder
+	// RenameColumn adds the RENAME COLUMN action for the specified column.
+	RenameColumn(name string, newName string) AlterTableBuilder
+	// RenameTo adds the RENAME TO action renaming the table.
+	RenameTo(newName string) AlterTableBuilder
+	// AlterColumnType adds the action changing the data type of the specified column.
+	// MySQL redefines the column with MODIFY COLUMN, which drops its NOT NULL and DEFAULT attributes.
+	AlterColumnType(name string, typ ColumnType) AlterTableBuilder
+	// SetNotNull adds the action adding the NOT NULL constraint to the specified column.
+	SetNotNull(name string) AlterTableBuilder
+	// DropNotNull adds the action removing the NOT NULL constraint from the specified column.
+	DropNotNull(name string) AlterTableBuilder
+	// SetDefault adds the action setting the default value of the specified column.
+	SetDefault(name string, value any) AlterTableBuilder
+	// DropDefault adds the action removing the default value of the specified column.
+	DropDefault(name string) AlterTableBuilder
+	// AddConstraint adds the ADD action with the specified table constraint.
+	AddConstraint(constraint Constraint) AlterTableBuilder
+	// DropConstraint adds the DROP CONSTRAINT action for the specified constraint.
+	DropConstraint(name string) AlterTableBuilder
+	// PreparedSql generates the ALTER TABLE statements, which never have placeholders, based on the provided SqlOptions.
+	PreparedSql(options *driver.SqlOptions) (string, []any, error)
+	// Sql generates the ALTER TABLE statements with inlined literals based on the provided SqlOptions.
+	Sql(options *driver.SqlOptions) (string, []any, error)
+}
+
+// DropTableBuilder provides an interface for building SQL DROP TABLE statements.
+type DropTableBuilder interface {
+	// IfExists adds the IF EXISTS clause to the DROP TABLE statement.
+	IfExists() DropTableBuilder
+	// Cascade adds the CASCADE clause, which drops the objects depending on the tables.
+	Cascade() DropTableBuilder
+	// PreparedSql generates the DROP TABLE statement, which never has placeholders, based on the provided SqlOptions.
+	PreparedSql(options *driver.SqlOptions) (string, []any, error)
+	// Sql generates the DROP TABLE statement based on the provided SqlOptions.
+	Sql(options *driver.SqlOptions) (string, []any, error)
+}
+
+// alterActionType defines the type of the ALTER TABLE action.
+type alterActionType int
+
+// createTableBuilder is a structure for constructing SQL CREATE TABLE statements.
+type createTableBuilder struct {
+	table       Column
+	ifNotExists bool
+	columns     []ColumnDefBuilder
+	constraints []Constraint
+}
+
+// alterTableBuilder is a structure for constructing SQL ALTER TABLE statements.
+type alterTableBuilder struct {
+	table   Column
+	actions []alterAction
+}
+
+// alterAction represents a single action of the ALTER TABLE statement.
+type alterAction struct {
+	actionType alterActionType
+	column     Column
+	newName    Column
+	def        ColumnDefBuilder
+	typ        ColumnType
+	value      any
+	constraint Constraint
+}
+
+// dropTableBuilder is a structure for constructing SQL DROP TABLE statements.
+type dropTableBuilder struct {
+	tables   []Column
+	ifExists bool
+	cascade  bool
+}
+
+// alterAddColumn represents the ADD COLUMN action.
+// alterDropColumn represents the DROP COLUMN action.
+// alterRenameColumn represents the RENAME COLUMN action.
+// alterRenameTo represents the RENAME TO action.
+// alterColumnType represents the ALTER COLUMN ... TYPE action.
+// alterSetNotNull represents the ALTER COLUMN ... SET NOT NULL action.
+// alterDropNotNull represents the ALTER COLUMN ... DROP NOT NULL action.
+// alterSetDefault represents the ALTER COLUMN ... SET DEFAULT action.
+// alterDropDefault represents the ALTER COLUMN ... DROP DEFAULT action.
+// alterAddConstraint represents the ADD constraint action.
+// alterDropConstraint represents the DROP CONSTRAINT action.
+const (
+	alterAddColumn alterActionType = iota
+	alterDropColumn
+	alterRenameColumn
+	alterRenameTo
+	alterColumnType
+	alterSetNotNull
+	alterDropNotNull
+	alterSetDefault
+	alterDropDefault
+	alterAddConstraint
+	alterDropConstraint
+)
+
+// ensures that CreateTableBuilder, AlterTableBuilder and DropTableBuilder implement the PreparedSqler interface at compile-time.
+var (
+	_ driver.PreparedSqler = CreateTableBuilder(nil)
+	_ driver.PreparedSqler = AlterTableBuilder(nil)
+	_ driver.PreparedSqler = DropTableBuilder(nil)
+)
+
+// CreateTable creates a new CreateTableBuilder for the table with the specified name.
+func CreateTable(name string) CreateTableBuilder {
+	return &createTableBuilder{table: Column(name)}
+}
+
+// AlterTable creates a new AlterTableBuilder for the table with the specified name.
+func AlterTable(name string) AlterTableBuilder {
+	return &alterTableBuilder{table: Column(name)}
+}
+
+// DropTable creates a new DropTableBuilder for the tables with the specified names.
+func DropTable(names ...string) DropTableBuilder {
+	return &dropTableBuilder{tables: toColumns(names)}
+}
+
+// IfNotExists adds the IF NOT EXISTS clause and returns the updated CreateTableBuilder.
+func (ct *createTableBuilder) IfNotExists() CreateTableBuilder {
+	ct.ifNotExists = true
+	return ct
+}
+
+// Columns appends the column definitions and returns the updated CreateTableBuilder.
+func (ct *createTableBuilder) Columns(columns ...ColumnDefBuilder) CreateTableBuilder {
+	ct.columns = append(ct.columns, columns...)
+	return ct
+}
+
+// Constraints appends the table constraints and returns the updated CreateTableBuilder.
+func (ct *createTableBuilder) Constraints(constraints ...Constraint) CreateTableBuilder {
+	ct.constraints = append(ct.constraints, constraints...)
+	return ct
+}
+
+// Sql generates the CREATE TABLE statement, such as `CREATE TABLE "users" ("id" BIGSERIAL PRIMARY KEY,...)`.
+// The literals are inlined since DDL statements can't have placeholders.
+func (ct *createTableBuilder) Sql(options *driver.SqlOptions) (string, []any, error) {
+	return ddlSql(ct.sql, options)
+}
+
+// PreparedSql generates the CREATE TABLE statement. The statement has no placeholders and arguments.
+func (ct *createTableBuilder) PreparedSql(options *driver.SqlOptions) (string, []any, error) {
+	return ct.Sql(options)
+}
+
+// sql generates the CREATE TABLE statement with placeholders for the literals.
+func (ct *createTableBuilder) sql(options *driver.SqlOptions) (string, []any, error) {
+	if len(ct.columns) == 0 {
+		return "", nil, fmt.Errorf("create table: %w", ErrFieldsEmpty)
+	}
+
+	var buf strings.Builder
+	var args []any
+	if ct.ifNotExists && options.Dialect == driver.DialectMssql {
+		// SQL Server has no IF NOT EXISTS clause, so the statement is guarded by the lookup of the table.
+		buf.WriteString("IF OBJECT_ID(" + string(driver.Placeholder) + ",'U') IS NULL ")
+		args = append(args, string(ct.table))
+	}
+
+	buf.WriteString("CREATE TABLE ")
+	if ct.ifNotExists && options.Dialect != driver.DialectMssql {
+		buf.WriteString("IF NOT EXISTS ")
+	}
+
+	sql, tableArgs, err := ct.table.Sql(options)
+	if err != nil {
+		return "", nil, err
+	}
+
+	args = append(args, tableArgs...)
+
+	buf.WriteString(sql)
+	buf.WriteString(" (")
+
+	for i := range ct.columns {
+		if ct.columns[i] == nil {
+			return "", nil, fmt.Errorf("create table: column definition can't be nil")
+		}
+
+		sqlCol, colArgs, err := ct.columns[i].Sql(options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		if i != 0 {
+			buf.WriteByte(',')
+		}
+
+		args = append(args, colArgs...)
+		buf.WriteString(sqlCol)
+	}
+
+	for i := range ct.constraints {
+		if ct.constraints[i] == nil {
+			return "", nil, fmt.Errorf("create table: constraint can't be nil")
+		}
+
+		sqlCon, conArgs, err := ct.constraints[i].Sql(options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		args = append(args, conArgs...)
+		buf.WriteByte(',')
+		buf.WriteString(sqlCon)
+	}
+
+	buf.WriteByte(')')
+	return buf.String(), args, nil
+}
+
+// AddColumn appends the ADD COLUMN action and returns the updated AlterTableBuilder.
+func (at *alterTableBuilder) AddColumn(column ColumnDefBuilder) AlterTableBuilder {
+	at.actions = append(at.actions, alterAction{actionType: alterAddColumn, def: column})
+	return at
+}
+
+// DropColumn appends the DROP COLUMN action and returns the updated AlterTableBuilder.
+func (at *alterTableBuilder) DropColumn(name string) AlterTableBuilder {
+	at.actions = append(at.actions, alterAction{actionType: alterDropColumn, column: Column(name)})
+	return at
+}
+
+// RenameColumn appends the RENAME COLUMN action and returns the updated AlterTableBuilder.
+func (at *alterTableBuilder) RenameColumn(name string, newName string) AlterTableBuilder {
+	at.actions = append(at.actions, alterAction{actionType: alterRenameColumn, column: Column(name), newName: Column(newName)})
+	return at
+}
+
+// RenameTo appends the RENAME TO action and returns the updated AlterTableBuilder.
+func (at *alterTableBuilder) RenameTo(newName string) AlterTableBuilder {
+	at.actions = append(at.actions, alterAction{actionType: alterRenameTo, newName: Column(newName)})
+	return at
+}
+
+// AlterColumnType appends the action changing the data type of the column and returns the updated AlterTableBuilder.
+func (at *alterTableBuilder) AlterColumnType(name string, typ ColumnType) AlterTableBuilder {
+	at.actions = append(at.actions, alterAction{actionType: alterColumnType, column: Column(name), typ: typ})
+	return at
+}
+
+// SetNotNull appends the SET NOT NULL action and returns the updated AlterTableBuilder.
+func (at *alterTableBuilder) SetNotNull(name string) AlterTableBuilder {
+	at.actions = append(at.actions, alterAction{actionType: alterSetNotNull, column: Column(name)})
+	return at
+}
+
+// DropNotNull appends the DROP NOT NULL action and returns the updated AlterTableBuilder.
+func (at *alterTableBuilder) DropNotNull(name string) AlterTableBuilder {
+	at.actions = append(at.actions, alterAction{actionType: alterDropNotNull, column: Column(name)})
+	return at
+}
+
+// SetDefault appends the SET DEFAULT action and returns the updated AlterTableBuilder.
+// The value can be a literal or an expression implementing Sqler.
+func (at *alterTableBuilder) SetDefault(name string, value any) AlterTableBuilder {
+	at.actions = append(at.actions, alterAction{actionType: alterSetDefault, column: Column(name), value: value})
+	return at
+}
+
+// DropDefault appends the DROP DEFAULT action and returns the updated AlterTableBuilder.
+func (at *alterTableBuilder) DropDefault(name string) AlterTableBuilder {
+	at.actions = append(at.actions, alterAction{actionType: alterDropDefault, column: Column(name)})
+	return at
+}
+
+// AddConstraint appends the ADD constraint action and returns the updated AlterTableBuilder.
+func (at *alterTableBuilder) AddConstraint(constraint Constraint) AlterTableBuilder {
+	at.actions = append(at.actions, alterAction{actionType: alterAddConstraint, constraint: constraint})
+	return at
+}
+
+// DropConstraint appends the DROP CONSTRAINT action and returns the updated AlterTableBuilder.
+func (at *alterTableBuilder) DropConstraint(name string) AlterTableBuilder {
+	at.actions = append(at.actions, alterAction{actionType: alterDropConstraint, column: Column(name)})
+	return at
+}
+
+// Sql generates the ALTER TABLE statements separated by semicolons.
+// Postgres combines consecutive actions into a single statement, except renaming, which requires a separate statement.
+// Sqlite and SQL Server require a separate statement for each action.
+func (at *alterTableBuilder) Sql(options *driver.SqlOptions) (string, []any, error) {
+	return ddlSql(at.sql, options)
+}
+
+// PreparedSql generates the ALTER TABLE statements. The statements have no placeholders and arguments.
+func (at *alterTableBuilder) PreparedSql(options *driver.SqlOptions) (string, []any, error) {
+	return at.Sql(options)
+}
+
+// sql generates the ALTER TABLE statements with placeholders for the literals.
+func (at *alterTableBuilder) sql(options *driver.SqlOptions) (string, []any, error) {
+	if len(at.actions) == 0 {
+		return "", nil, fmt.Errorf("alter table: no actions")
+	}
+
+	sqlTable, args, err := at.table.Sql(options)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var buf strings.Builder
+	combine := false
+	for i := range at.actions {
+		sqlAction, actionArgs, err := at.actions[i].Sql(options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		args = append(args, actionArgs...)
+		if combine && at.actions[i].combinable(options) {
+			buf.WriteByte(',')
+		} else {
+			if i != 0 {
+				buf.WriteString("; ")
+			}
+
+			buf.WriteString("ALTER TABLE ")
+			buf.WriteString(sqlTable)
+			buf.WriteByte(' ')
+		}
+
+		buf.WriteString(sqlAction)
+		combine = at.actions[i].combinable(options)
+	}
+
+	return buf.String(), args, nil
+}
+
+// combinable reports whether the action can be combined with other actions into a single ALTER TABLE statement.
+func (aa alterAction) combinable(options *driver.SqlOptions) bool {
+	return options.Dialect != driver.DialectSqlite && options.Dialect != driver.DialectMssql &&
+		aa.actionType != alterRenameColumn && aa.actionType != alterRenameTo
+}
+
+// Sql generates the action of the ALTER TABLE statement, such as `ADD COLUMN "name" TEXT`.
+// Returns an error if the dialect of the SqlOptions doesn't support the action.
+func (aa alterAction) Sql(options *driver.SqlOptions) (string, []any, error) {
+	if options.Dialect == driver.DialectSqlite {
+		switch aa.actionType {
+		case alterAddColumn, alterDropColumn, alterRenameColumn, alterRenameTo:
+		default:
+			return "", nil, fmt.Errorf("alter table %s: %w %q", aa.actionType, ErrUnsupportedDialect, options.Dialect)
+		}
+	}
+
+	if options.Dialect == driver.DialectMysql {
+		switch aa.actionType {
+		case alterSetNotNull, alterDropNotNull:
+			return "", nil, fmt.Errorf("alter table %s: %w %q", aa.actionType, ErrUnsupportedDialect, options.Dialect)
+		}
+	}
+
+	if options.Dialect == driver.DialectMssql {
+		switch aa.actionType {
+		case alterAddColumn, alterDropColumn, alterColumnType, alterAddConstraint, alterDropConstraint:
+		default:
+			return "", nil, fmt.Errorf("alter table %s: %w %q", aa.actionType, ErrUnsupportedDialect, options.Dialect)
+		}
+	}
+
+	switch aa.actionType {
+	case alterAddColumn:
+		if aa.def == nil {
+			return "", nil, fmt.Errorf("alter table add column: column definition can't be nil")
+		}
+
+		sql, args, err := aa.def.Sql(options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		if options.Dialect == driver.DialectMssql {
+			return "ADD " + sql, args, nil
+		}
+
+		return "ADD COLUMN " + sql, args, nil
+	case alterRenameTo:
+		sql, args, err := aa.newName.Sql(options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		return "RENAME TO " + sql, args, nil
+	case alterAddConstraint:
+		if aa.constraint == nil {
+			return "", nil, fmt.Errorf("alter table add constraint: constraint can't be nil")
+		}
+
+		sql, args, err := aa.constraint.Sql(options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		return "ADD " + sql, args, nil
+	case alterDropConstraint:
+		sql, args, err := aa.column.Sql(options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		return "DROP CONSTRAINT " + sql, args, nil
+	}
+
+	sql, args, err := aa.column.Sql(options)
+	if err != nil {
+		return "", nil, err
+	}
+
+	switch aa.actionType {
+	case alterDropColumn:
+		return "DROP COLUMN " + sql, args, nil
+	case alterRenameColumn:
+		sqlName, nameArgs, err := aa.newName.Sql(options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		args = append(args, nameArgs...)
+		return "RENAME COLUMN " + sql + " TO " + sqlName, args, nil
+	case alterColumnType:
+		if aa.typ == nil {
+			return "", nil, fmt.Errorf("alter table alter column type: column %q requires a data type", aa.column)
+		}
+
+		sqlType, typeArgs, err := aa.typ.Sql(options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		args = append(args, typeArgs...)
+		switch options.Dialect {
+		case driver.DialectMysql:
+			return "MODIFY COLUMN " + sql + " " + sqlType, args, nil
+		case driver.DialectMssql:
+			return "ALTER COLUMN " + sql + " " + sqlType, args, nil
+		}
+
+		return "ALTER COLUMN " + sql + " TYPE " + sqlType, args, nil
+	case alterSetNotNull:
+		return "ALTER COLUMN " + sql + " SET NOT NULL", args, nil
+	case alterDropNotNull:
+		return "ALTER COLUMN " + sql + " DROP NOT NULL", args, nil
+	case alterSetDefault:
+		if exp, ok := aa.value.(driver.Sqler); ok {
+			sqlDef, defArgs, err := exp.Sql(options)
+			if err != nil {
+				return "", nil, err
+			}
+
+			args = append(args, defArgs...)
+			return "ALTER COLUMN " + sql + " SET DEFAULT (" + sqlDef + ")", args, nil
+		}
+
+		args = append(args, aa.value)
+		return "ALTER COLUMN " + sql + " SET DEFAULT " + string(driver.Placeholder), args, nil
+	}
+
+	return "ALTER COLUMN " + sql + " DROP DEFAULT", args, nil
+}
+
+// String returns the name of the alterActionType used in error messages, such as "add column".
+func (a alterActionType) String() string {
+	switch a {
+	case alterAddColumn:
+		return "add column"
+	case alterDropColumn:
+		return "drop column"
+	case alterRenameColumn:
+		return "rename column"
+	case alterRenameTo:
+		return "rename to"
+	case alterColumnType:
+		return "alter column type"
+	case alterSetNotNull:
+		return "set not null"
+	case alterDropNotNull:
+		return "drop not null"
+	case alterSetDefault:
+		return "set default"
+	case alterDropDefault:
+		return "drop default"
+	case alterAddConstraint:
+		return "add constraint"
+	}
+
+	return "drop constraint"
+}
+
+// IfExists adds the IF EXISTS clause and returns the updated DropTableBuilder.
+func (dt *dropTableBuilder) IfExists() DropTableBuilder {
+	dt.ifExists = true
+	return dt
+}
+
+// Cascade adds the CASCADE clause and returns the updated DropTableBuilder.
+func (dt *dropTableBuilder) Cascade() DropTableBuilder {
+	dt.cascade = true
+	return dt
+}
+
+// Sql generates the DROP TABLE statement, such as `DROP TABLE IF EXISTS "users","roles" CASCADE`.
+// Sqlite drops one table per statement, so a separate statement is generated for each table.
+func (dt *dropTableBuilder) Sql(options *driver.SqlOptions) (string, []any, error) {
+	if len(dt.tables) == 0 {
+		return "", nil, fmt.Errorf("drop table: %w", ErrFieldsEmpty)
+	}
+
+	if dt.cascade && (options.Dialect == driver.DialectSqlite || options.Dialect == driver.DialectMssql) {
+		return "", nil, fmt.Errorf("drop table cascade: %w %q", ErrUnsupportedDialect, options.Dialect)
+	}
+
+	prefix := "DROP TABLE "
+	if dt.ifExists {
+		prefix += "IF EXISTS "
+	}
+
+	if options.Dialect == driver.DialectSqlite {
+		var buf strings.Builder
+		var args []any
+		for i := range dt.tables {
+			sql, tableArgs, err := dt.tables[i].Sql(options)
+			if err != nil {
+				return "", nil, err
+			}
+
+			if i != 0 {
+				buf.WriteString("; ")
+			}
+
+			args = append(args, tableArgs...)
+			buf.WriteString(prefix + sql)
+		}
+
+		return buf.String(), args, nil
+	}
+
+	sql, args, err := concatFields(dt.tables, options)
+	if err != nil {
+		return "", nil, err
+	}
+
+	sql = prefix + sql
+	if dt.cascade {
+		sql += " CASCADE"
+	}
+
+	return sql, args, nil
+}
+
+// PreparedSql generates the DROP TABLE statement. The statement has no placeholders and arguments.
+func (dt *dropTableBuilder) PreparedSql(options *driver.SqlOptions) (string, []any, error) {
+	return dt.Sql(options)
+}
@@ -0,0 +1,273 @@
+package op
+
+import (
+	"testing"
+
+	"github.com/xsqrty/op/driver"
+	"github.com/xsqrty/op/internal/testutil"
+)
+
+func TestCreateTable(t *testing.T) {
+	t.Parallel()
+	users := func() CreateTableBuilder {
+		return CreateTable("users").
+			IfNotExists().
+			Columns(
+				ColumnDef("id", TypeBigSerial()).PrimaryKey(),
+				ColumnDef("name", TypeVarchar(100)).NotNull(),
+				ColumnDef("email", TypeText()).NotNull().Unique(),
+				ColumnDef("role", TypeText()).NotNull().Default("user's"),
+				ColumnDef("active", TypeBoolean()).NotNull().Default(true),
+				ColumnDef("age", TypeInteger()).Check(Gte("age", 18)),
+				ColumnDef("company_id", TypeBigInt()).References("companies", "id").OnDelete(ReferenceSetNull),
+				ColumnDef("created_at", TypeTimestampTz()).NotNull().Default(driver.Pure("CURRENT_TIMESTAMP")),
+			)
+	}
+
+	testutil.RunCases(t, options, []testutil.TestCase{
+		{
+			Name:         "create_table",
+			Builder:      users(),
+			ExpectedSql:  `CREATE TABLE IF NOT EXISTS "users" ("id" BIGSERIAL PRIMARY KEY,"name" VARCHAR(100) NOT NULL,"email" TEXT NOT NULL UNIQUE,"role" TEXT NOT NULL DEFAULT 'user''s',"active" BOOLEAN NOT NULL DEFAULT TRUE,"age" INTEGER CHECK ("age" >= 18),"company_id" BIGINT REFERENCES "companies" ("id") ON DELETE SET NULL,"created_at" TIMESTAMPTZ NOT NULL DEFAULT (CURRENT_TIMESTAMP))`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "create_table_sqlite",
+			Builder:      users(),
+			SqlOptions:   testutil.NewDialectOptions(driver.DialectSqlite),
+			ExpectedSql:  `CREATE TABLE IF NOT EXISTS "users" ("id" INTEGER PRIMARY KEY,"name" TEXT NOT NULL,"email" TEXT NOT NULL UNIQUE,"role" TEXT NOT NULL DEFAULT 'user''s',"active" BOOLEAN NOT NULL DEFAULT TRUE,"age" INTEGER CHECK ("age" >= 18),"company_id" INTEGER REFERENCES "companies" ("id") ON DELETE SET NULL,"created_at" DATETIME NOT NULL DEFAULT (CURRENT_TIMESTAMP))`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name: "create_table_constraints",
+			Builder: CreateTable("memberships").
+				Columns(
+					ColumnDef("tenant_id", TypeBigInt()).NotNull(),
+					ColumnDef("user_id", TypeBigInt()).NotNull(),
+					ColumnDef("starts_at", TypeDate()).NotNull(),
+					ColumnDef("ends_at", TypeDate()),
+				).
+				Constraints(
+					PrimaryKey("tenant_id", "user_id"),
+					ForeignKey("user_id").References("users", "id").OnDelete(ReferenceCascade),
+					Check(Or{Eq("ends_at", nil), Gt("ends_at", Column("starts_at"))}).Named("memberships_period"),
+				),
+			ExpectedSql:  `CREATE TABLE "memberships" ("tenant_id" BIGINT NOT NULL,"user_id" BIGINT NOT NULL,"starts_at" DATE NOT NULL,"ends_at" DATE,PRIMARY KEY ("tenant_id","user_id"),FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE,CONSTRAINT "memberships_period" CHECK (("ends_at" IS NULL OR "ends_at" > "starts_at")))`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "create_table_literal_placeholder",
+			Builder:      CreateTable("users").Columns(ColumnDef("role", TypeText()).Default("?")),
+			SqlOptions:   testutil.NewDialectOptions(driver.DialectPostgres),
+			ExpectedSql:  `CREATE TABLE "users" ("role" TEXT DEFAULT '?')`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "create_table_literal_mysql",
+			Builder:      CreateTable("users").Columns(ColumnDef("path", TypeText()).Default(`x\'s\`)),
+			SqlOptions:   testutil.NewDialectOptions(driver.DialectMysql),
+			ExpectedSql:  `CREATE TABLE "users" ("path" TEXT DEFAULT 'x\\''s\\')`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "error_empty",
+			Builder:      CreateTable("users"),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  "create table: fields is empty",
+		},
+		{
+			Name:         "error_nil_column",
+			Builder:      CreateTable("users").Columns(nil),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  "create table: column definition can't be nil",
+		},
+		{
+			Name:         "error_nil_constraint",
+			Builder:      CreateTable("users").Columns(ColumnDef("id", TypeInteger())).Constraints(nil),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  "create table: constraint can't be nil",
+		},
+		{
+			Name:         "error_literal",
+			Builder:      CreateTable("users").Columns(ColumnDef("tags", TypeArray(TypeText())).Default([]string{"a"})),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  "unknown type: []string can't be used as a literal",
+		},
+		{
+			Name:         "error_table",
+			Builder:      CreateTable("a+b").Columns(ColumnDef("id", TypeInteger())),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `target "a+b" contains illegal character '+'`,
+		},
+	})
+}
+
+func TestAlterTable(t *testing.T) {
+	t.Parallel()
+	sqlite := testutil.NewDialectOptions(driver.DialectSqlite)
+	testutil.RunCases(t, options, []testutil.TestCase{
+		{
+			Name: "alter_table",
+			Builder: AlterTable("users").
+				AddColumn(ColumnDef("phone", TypeVarchar(20))).
+				DropColumn("legacy").
+				AlterColumnType("age", TypeSmallInt()).
+				SetNotNull("name").
+				DropNotNull("phone").
+				SetDefault("role", "user").
+				SetDefault("created_at", driver.Pure("CURRENT_TIMESTAMP")).
+				DropDefault("active"),
+			ExpectedSql:  `ALTER TABLE "users" ADD COLUMN "phone" VARCHAR(20),DROP COLUMN "legacy",ALTER COLUMN "age" TYPE SMALLINT,ALTER COLUMN "name" SET NOT NULL,ALTER COLUMN "phone" DROP NOT NULL,ALTER COLUMN "role" SET DEFAULT 'user',ALTER COLUMN "created_at" SET DEFAULT (CURRENT_TIMESTAMP),ALTER COLUMN "active" DROP DEFAULT`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name: "alter_table_constraints",
+			Builder: AlterTable("users").
+				AddConstraint(Unique("email").Named("users_email_key")).
+				DropConstraint("users_legacy_key"),
+			ExpectedSql:  `ALTER TABLE "users" ADD CONSTRAINT "users_email_key" UNIQUE ("email"),DROP CONSTRAINT "users_legacy_key"`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name: "alter_table_rename",
+			Builder: AlterTable("users").
+				AddColumn(ColumnDef("phone", TypeText())).
+				DropColumn("legacy").
+				RenameColumn("name", "full_name").
+				AddColumn(ColumnDef("bio", TypeText())).
+				RenameTo("accounts"),
+			ExpectedSql:  `ALTER TABLE "users" ADD COLUMN "phone" TEXT,DROP COLUMN "legacy"; ALTER TABLE "users" RENAME COLUMN "name" TO "full_name"; ALTER TABLE "users" ADD COLUMN "bio" TEXT; ALTER TABLE "users" RENAME TO "accounts"`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name: "alter_table_sqlite",
+			Builder: AlterTable("users").
+				AddColumn(ColumnDef("phone", TypeVarchar(20)).Default("none")).
+				DropColumn("legacy").
+				RenameColumn("name", "full_name"),
+			SqlOptions:   sqlite,
+			ExpectedSql:  `ALTER TABLE "users" ADD COLUMN "phone" TEXT DEFAULT 'none'; ALTER TABLE "users" DROP COLUMN "legacy"; ALTER TABLE "users" RENAME COLUMN "name" TO "full_name"`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "error_empty",
+			Builder:      AlterTable("users"),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  "alter table: no actions",
+		},
+		{
+			Name:         "error_sqlite_set_not_null",
+			Builder:      AlterTable("users").SetNotNull("name"),
+			SqlOptions:   sqlite,
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `alter table set not null: unsupported in dialect "sqlite"`,
+		},
+		{
+			Name:         "alter_table_mysql",
+			Builder:      AlterTable("users").AlterColumnType("age", TypeSmallInt()).SetDefault("role", "user"),
+			SqlOptions:   testutil.NewDialectOptions(driver.DialectMysql),
+			ExpectedSql:  `ALTER TABLE "users" MODIFY COLUMN "age" SMALLINT,ALTER COLUMN "role" SET DEFAULT 'user'`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "error_mysql_drop_not_null",
+			Builder:      AlterTable("users").DropNotNull("name"),
+			SqlOptions:   testutil.NewDialectOptions(driver.DialectMysql),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `alter table drop not null: unsupported in dialect "mysql"`,
+		},
+		{
+			Name:         "error_sqlite_add_constraint",
+			Builder:      AlterTable("users").AddConstraint(Unique("email")),
+			SqlOptions:   sqlite,
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `alter table add constraint: unsupported in dialect "sqlite"`,
+		},
+		{
+			Name:         "error_nil_column",
+			Builder:      AlterTable("users").AddColumn(nil),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  "alter table add column: column definition can't be nil",
+		},
+		{
+			Name:         "error_nil_constraint",
+			Builder:      AlterTable("users").AddConstraint(nil),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  "alter table add constraint: constraint can't be nil",
+		},
+		{
+			Name:         "error_nil_type",
+			Builder:      AlterTable("users").AlterColumnType("age", nil),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `alter table alter column type: column "age" requires a data type`,
+		},
+		{
+			Name:         "error_column",
+			Builder:      AlterTable("users").DropColumn("a+b"),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `target "a+b" contains illegal character '+'`,
+		},
+	})
+}
+
+func TestDropTable(t *testing.T) {
+	t.Parallel()
+	sqlite := testutil.NewDialectOptions(driver.DialectSqlite)
+	testutil.RunCases(t, options, []testutil.TestCase{
+		{
+			Name:         "drop_table",
+			Builder:      DropTable("users"),
+			ExpectedSql:  `DROP TABLE "users"`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "drop_tables_cascade",
+			Builder:      DropTable("users", "roles").IfExists().Cascade(),
+			ExpectedSql:  `DROP TABLE IF EXISTS "users","roles" CASCADE`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "drop_tables_sqlite",
+			Builder:      DropTable("users", "roles").IfExists(),
+			SqlOptions:   sqlite,
+			ExpectedSql:  `DROP TABLE IF EXISTS "users"; DROP TABLE IF EXISTS "roles"`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "error_empty",
+			Builder:      DropTable(),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  "drop table: fields is empty",
+		},
+		{
+			Name:         "error_sqlite_cascade",
+			Builder:      DropTable("users").Cascade(),
+			SqlOptions:   sqlite,
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `drop table cascade: unsupported in dialect "sqlite"`,
+		},
+		{
+			Name:         "error_sqlite_table",
+			Builder:      DropTable("a+b"),
+			SqlOptions:   sqlite,
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `target "a+b" contains illegal character '+'`,
+		},
+	})
+}
@@ -9,6 +9,15 @@ import (
 
 // UpdateBuilder is an interface for building SQL UPDATE statements with optional WHERE and RETURNING clauses.
 type UpdateBuilder interface {
+	// Cte adds a named common table expression to the WITH clause, optionally with the list of its columns.
+	Cte(name string, query driver.Sqler, columns ...string) UpdateBuilder
+	// CteRecursive adds a named common table expression to the WITH clause and marks the clause as RECURSIVE.
+	CteRecursive(name string, query driver.Sqler, columns ...string) UpdateBuilder
+	// From adds one or more tables to the FROM clause of the UPDATE statement.
+	From(tables ...any) UpdateBuilder
+	// Join adds the table to the FROM clause of the UPDATE statement and the join condition to the WHERE clause.
+	// MySQL has no FROM clause in UPDATE, the tables of From and Join are listed after the target of the multi-table UPDATE.
+	Join(table any, on driver.Sqler) UpdateBuilder
 	// Where adds a condition to the WHERE clause of the UPDATE statement.
 	Where(exp driver.Sqler) UpdateBuilder
 	// Returning adds keys to the RETURNING clause of the UPDATE statement.
@@ -36,10 +45,13 @@ type Updates map[string]any
 
 // updateBuilder is an internal implementation for building SQL UPDATE statements with support for WHERE and RETURNING clauses.
 type updateBuilder struct {
+	ctes          ctes
 	table         Alias
 	returningKeys []Alias
 	updatesKeys   []Column
 	updatesVals   []driver.Sqler
+	from          []Alias
+	joins         []join
 	where         And
 	err           error
 }
@@ -66,6 +78,51 @@ func Update(table any, updates Updates) UpdateBuilder {
 	return ub
 }
 
+// Cte adds a common table expression with the given name, query, and optional columns to the WITH clause.
+func (ub *updateBuilder) Cte(name string, query driver.Sqler, columns ...string) UpdateBuilder {
+	ub.ctes.add(name, query, columns, false)
+	return ub
+}
+
+// CteRecursive adds a common table expression to the WITH clause and renders the clause as WITH RECURSIVE.
+func (ub *updateBuilder) CteRecursive(name string, query driver.Sqler, columns ...string) UpdateBuilder {
+	ub.ctes.add(name, query, columns, true)
+	return ub
+}
+
+// From appends the tables to the FROM clause of the UPDATE statement. Tables must be strings or Alias values.
+// Returns the UpdateBuilder for chaining.
+func (ub *updateBuilder) From(tables ...any) UpdateBuilder {
+	for i := range tables {
+		switch val := tables[i].(type) {
+		case string:
+			ub.from = append(ub.from, ColumnAlias(Column(val)))
+		case Alias:
+			ub.from = append(ub.from, val)
+		default:
+			ub.err = fmt.Errorf("%w: %T must be a string or Alias", ErrUnsupportedType, tables[i])
+			return ub
+		}
+	}
+
+	return ub
+}
+
+// Join appends the table to the FROM clause and the ON condition to the WHERE clause of the UPDATE statement.
+// The table must be a string or an Alias. Returns the UpdateBuilder for chaining.
+func (ub *updateBuilder) Join(table any, on driver.Sqler) UpdateBuilder {
+	switch val := table.(type) {
+	case string:
+		ub.joins = append(ub.joins, join{table: ColumnAlias(Column(val)), on: on, joinType: joinDefault})
+	case Alias:
+		ub.joins = append(ub.joins, join{table: val, on: on, joinType: joinDefault})
+	default:
+		ub.err = fmt.Errorf("%w: %T must be a string or Alias", ErrUnsupportedType, table)
+	}
+
+	return ub
+}
+
 // Where adds a condition to the WHERE clause of the SQL UPDATE statement. Returns the UpdateBuilder for chaining.
 func (ub *updateBuilder) Where(exp driver.Sqler) UpdateBuilder {
 	if exp != nil {
@@ -95,9 +152,45 @@ func (ub *updateBuilder) Sql(options *driver.SqlOptions) (string, []any, error)
 		return "", nil, fmt.Errorf("update: %w", ErrFieldsEmpty)
 	}
 
+	from := ub.from
+	where := ub.where
+	if len(ub.joins) > 0 {
+		from = make([]Alias, 0, len(ub.from)+len(ub.joins))
+		from = append(from, ub.from...)
+		where = make(And, 0, len(ub.joins)+len(ub.where))
+		for i := range ub.joins {
+			if ub.joins[i].on == nil {
+				return "", nil, fmt.Errorf(
+					"%s operation requires an ON clause to specify join condition",
+					ub.joins[i].joinType,
+				)
+			}
+
+			if _, ok := ub.joins[i].on.(*using); ok {
+				return "", nil, fmt.Errorf("%s operation of UPDATE requires an ON clause instead of USING", ub.joins[i].joinType)
+			}
+
+			from = append(from, ub.joins[i].table)
+			where = append(where, ub.joins[i].on)
+		}
+
+		where = append(where, ub.where...)
+	}
+
 	var buf strings.Builder
 	var args []any
 
+	if len(ub.ctes.items) > 0 {
+		sqlCtes, ctesArgs, err := ub.ctes.Sql(options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		args = append(args, ctesArgs...)
+		buf.WriteString(sqlCtes)
+		buf.WriteByte(' ')
+	}
+
 	buf.WriteString("UPDATE ")
 	if ub.table != nil {
 		sqlTable, tableArgs, err := ub.table.Sql(options)
@@ -107,6 +200,19 @@ func (ub *updateBuilder) Sql(options *driver.SqlOptions) (string, []any, error)
 
 		args = append(args, tableArgs...)
 		buf.WriteString(sqlTable)
+
+		// MySQL has no FROM clause in UPDATE, the joined tables are listed after the target of the multi-table UPDATE.
+		if len(from) > 0 && options.Dialect == driver.DialectMysql {
+			sql, fromArgs, err := concatFields(from, options)
+			if err != nil {
+				return "", nil, err
+			}
+
+			args = append(args, fromArgs...)
+			buf.WriteByte(options.FieldsDelim)
+			buf.WriteString(sql)
+		}
+
 		buf.WriteByte(' ')
 	}
 
@@ -119,9 +225,30 @@ func (ub *updateBuilder) Sql(options *driver.SqlOptions) (string, []any, error)
 	args = append(args, updatesArgs...)
 	buf.WriteString(sqlUpdates)
 
-	if len(ub.where) > 0 {
+	if len(ub.returningKeys) > 0 && options.Dialect == driver.DialectMssql {
+		sqlOutput, outputArgs, err := outputSql("INSERTED", ub.returningKeys, options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		args = append(args, outputArgs...)
+		buf.WriteString(sqlOutput)
+	}
+
+	if len(from) > 0 && options.Dialect != driver.DialectMysql {
+		buf.WriteString(" FROM ")
+		sql, fromArgs, err := concatFields(from, options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		args = append(args, fromArgs...)
+		buf.WriteString(sql)
+	}
+
+	if len(where) > 0 {
 		buf.WriteString(" WHERE ")
-		sql, whereArgs, err := ub.where.Sql(options)
+		sql, whereArgs, err := where.Sql(options)
 		if err != nil {
 			return "", nil, err
 		}
@@ -130,7 +257,11 @@ func (ub *updateBuilder) Sql(options *driver.SqlOptions) (string, []any, error)
 		buf.WriteString(sql)
 	}
 
-	if len(ub.returningKeys) > 0 {
+	if len(ub.returningKeys) > 0 && options.Dialect != driver.DialectMssql {
+		if options.Dialect == driver.DialectMysql {
+			return "", nil, fmt.Errorf("returning: %w %q", ErrUnsupportedDialect, options.Dialect)
+		}
+
 		buf.WriteString(" RETURNING ")
 		sqlRet, retArgs, err := concatFields(ub.returningKeys, options)
 		if err != nil {
@@ -160,9 +291,21 @@ func (ub *updateBuilder) With() string {
 	return ub.table.Alias()
 }
 
-// UsingTables returns a slice of strings containing the alias of the table associated with the updateBuilder.
+// UsingTables returns a slice of strings containing the alias of the table associated with the updateBuilder,
+// followed by the aliases of the tables of the FROM clause and the joined tables.
 func (ub *updateBuilder) UsingTables() []string {
-	return []string{ub.table.Alias()}
+	usingTables := make([]string, 0, len(ub.from)+len(ub.joins)+1)
+	usingTables = append(usingTables, ub.table.Alias())
+
+	for i := range ub.from {
+		usingTables = append(usingTables, ub.from[i].Alias())
+	}
+
+	for i := range ub.joins {
+		usingTables = append(usingTables, ub.joins[i].table.Alias())
+	}
+
+	return usingTables
 }
 
 // GetReturning retrieves the list of aliases specified in the RETURNING clause of the SQL UPDATE statement.
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"github.com/xsqrty/op/driver"
 	"github.com/xsqrty/op/internal/testutil"
 )
 
@@ -50,6 +51,43 @@ func TestUpdate(t *testing.T) {
 			ExpectedSql:  `UPDATE "users" SET "key"=? WHERE "name" LIKE ? RETURNING "id","name"`,
 			ExpectedArgs: []any{"value", "Al%"},
 		},
+		{
+			Name: "update_from",
+			Builder: Update("users", Updates{"company_name": Column("companies.name")}).
+				From("companies").
+				Where(Eq("users.company_id", Column("companies.id"))),
+			ExpectedSql:  `UPDATE "users" SET "company_name"="companies"."name" FROM "companies" WHERE "users"."company_id" = "companies"."id"`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name: "update_join",
+			Builder: Update("users", Updates{"company_name": Column("companies.name")}).
+				Join("companies", Eq("users.company_id", Column("companies.id"))).
+				Join(As("c", Select("id").From("countries")), Eq("companies.country_id", Column("c.id"))).
+				Where(Eq("users.active", true)).
+				Returning("users.id", "companies.name"),
+			ExpectedSql:  `UPDATE "users" SET "company_name"="companies"."name" FROM "companies",(SELECT "id" FROM "countries") AS "c" WHERE ("users"."company_id" = "companies"."id" AND "companies"."country_id" = "c"."id" AND "users"."active" = ?) RETURNING "users"."id","companies"."name"`,
+			ExpectedArgs: []any{true},
+		},
+		{
+			Name: "update_from_join",
+			Builder: Update("users", Updates{"role": Column("roles.name")}).
+				From(ColumnAlias("roles")).
+				Join("companies", Eq("users.company_id", Column("companies.id"))).
+				Where(Eq("roles.id", Column("companies.role_id"))),
+			ExpectedSql:  `UPDATE "users" SET "role"="roles"."name" FROM "roles","companies" WHERE ("users"."company_id" = "companies"."id" AND "roles"."id" = "companies"."role_id")`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name: "update_from_join_mysql",
+			Builder: Update("users", Updates{"users.role": Column("roles.name")}).
+				From(ColumnAlias("roles")).
+				Join(As("c", Select("id").From("companies").Where(Eq("active", true))), Eq("users.company_id", Column("c.id"))).
+				Where(Eq("roles.id", 1)),
+			SqlOptions:   testutil.NewDialectOptions(driver.DialectMysql),
+			ExpectedSql:  `UPDATE "users","roles",(SELECT "id" FROM "companies" WHERE "active" = ?) AS "c" SET "users"."role"="roles"."name" WHERE ("users"."company_id" = "c"."id" AND "roles"."id" = ?)`,
+			ExpectedArgs: []any{true, 1},
+		},
 		{
 			Name:         "error_table_1",
 			Builder:      Update("a+b", Updates{"key": "value"}),
@@ -92,6 +130,41 @@ func TestUpdate(t *testing.T) {
 			ExpectedArgs: []any(nil),
 			ExpectedErr:  `target "a+b" contains illegal character '+'`,
 		},
+		{
+			Name:         "error_from_type",
+			Builder:      Update("users", Updates{"key": "value"}).From(1),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  "unknown type: int must be a string or Alias",
+		},
+		{
+			Name:         "error_from",
+			Builder:      Update("users", Updates{"key": "value"}).From("a+b"),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `target "a+b" contains illegal character '+'`,
+		},
+		{
+			Name:         "error_join_type",
+			Builder:      Update("users", Updates{"key": "value"}).Join(1, Eq("a", 1)),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  "unknown type: int must be a string or Alias",
+		},
+		{
+			Name:         "error_join_on",
+			Builder:      Update("users", Updates{"key": "value"}).Join("companies", nil),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  "JOIN operation requires an ON clause to specify join condition",
+		},
+		{
+			Name:         "error_join_using",
+			Builder:      Update("users", Updates{"key": "value"}).Join("companies", Using("company_id")),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  "JOIN operation of UPDATE requires an ON clause instead of USING",
+		},
 	})
 }
 
@@ -102,6 +175,9 @@ func TestUpdateUsingTables(t *testing.T) {
 
 	require.Equal(t, []string{"users"}, tables)
 	require.Equal(t, "users", item.With())
+
+	item = Update("users", Updates{}).From("roles").Join(As("c", Select().From("companies")), Eq("a", 1))
+	require.Equal(t, []string{"users", "roles", "c"}, item.UsingTables())
 }
 
 func TestUpdateReturning(t *testing.T) {
@@ -9,8 +9,9 @@ import (
 )
 
 var (
-	ErrUnsupportedType = errors.New("unknown type")
-	ErrFieldsEmpty     = errors.New("fields is empty")
+	ErrUnsupportedType    = errors.New("unknown type")
+	ErrFieldsEmpty        = errors.New("fields is empty")
+	ErrUnsupportedDialect = errors.New("unsupported in dialect")
 )
 
 // exprOrCol processes an input value as either a column name or a SQL expression and generates its SQL representation.
@@ -0,0 +1,338 @@
+package op
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/xsqrty/op/driver"
+)
+
+// WindowBuilder provides an interface for building window definitions used by OVER and WINDOW clauses.
+type WindowBuilder interface {
+	// PartitionBy adds one or more columns or expressions to the PARTITION BY clause of the window.
+	PartitionBy(partitions ...any) WindowBuilder
+	// OrderBy adds one or more ordering criteria to the ORDER BY clause of the window.
+	OrderBy(orders ...Order) WindowBuilder
+	// Rows sets the frame clause of the window in ROWS mode. If end is nil, only the frame start is rendered.
+	Rows(start driver.Sqler, end driver.Sqler) WindowBuilder
+	// Range sets the frame clause of the window in RANGE mode. If end is nil, only the frame start is rendered.
+	Range(start driver.Sqler, end driver.Sqler) WindowBuilder
+	// Sql generates the window definition without surrounding parentheses.
+	Sql(options *driver.SqlOptions) (string, []any, error)
+}
+
+// frameType defines the mode of the window frame, such as ROWS or RANGE.
+type frameType int
+
+// frame represents the frame clause of the window with its mode and bounds.
+type frame struct {
+	frameType frameType
+	start     driver.Sqler
+	end       driver.Sqler
+}
+
+// window represents a window definition with an optional base window name, partitions, orders, and frame.
+type window struct {
+	base       Column
+	partitions []driver.Sqler
+	orders     []Order
+	frame      *frame
+	err        error
+}
+
+// namedWindow represents a named window definition of the WINDOW clause.
+type namedWindow struct {
+	name   Column
+	window WindowBuilder
+}
+
+// over represents a window function call with the OVER clause.
+type over struct {
+	fn     driver.Sqler
+	window any
+}
+
+// frameRows represents the ROWS frame mode.
+// frameRange represents the RANGE frame mode.
+const (
+	frameRows frameType = iota
+	frameRange
+)
+
+// Window creates a new WindowBuilder. If base is specified, the window extends the named window with the given name.
+func Window(base ...string) WindowBuilder {
+	w := &window{}
+	if len(base) > 0 {
+		w.base = Column(base[0])
+	}
+
+	return w
+}
+
+// Over creates a window function call `fn OVER (...)`.
+// The window must be a string referencing a named window, or a WindowBuilder describing the window inline.
+func Over(fn driver.Sqler, window any) driver.Sqler {
+	return &over{fn: fn, window: window}
+}
+
+// RowNumber generates a SQL ROW_NUMBER window function, numbering the rows within the window partition.
+func RowNumber() driver.Sqler {
+	return Func("ROW_NUMBER")
+}
+
+// Rank generates a SQL RANK window function, ranking the rows within the window partition with gaps.
+func Rank() driver.Sqler {
+	return Func("RANK")
+}
+
+// DenseRank generates a SQL DENSE_RANK window function, ranking the rows within the window partition without gaps.
+func DenseRank() driver.Sqler {
+	return Func("DENSE_RANK")
+}
+
+// PercentRank generates a SQL PERCENT_RANK window function, returning the relative rank of the current row.
+func PercentRank() driver.Sqler {
+	return Func("PERCENT_RANK")
+}
+
+// CumeDist generates a SQL CUME_DIST window function, returning the cumulative distribution of the current row.
+func CumeDist() driver.Sqler {
+	return Func("CUME_DIST")
+}
+
+// Ntile generates a SQL NTILE window function, dividing the rows of the partition into the specified number of buckets.
+func Ntile(buckets any) driver.Sqler {
+	return Func("NTILE", buckets)
+}
+
+// Lag generates a SQL LAG window function, returning the value of the row before the current row.
+// Optional args are the offset and the default value. String values are interpreted as columns.
+func Lag(arg any, args ...any) driver.Sqler {
+	return manyArgsColumn("LAG", append([]any{arg}, args...))
+}
+
+// Lead generates a SQL LEAD window function, returning the value of the row after the current row.
+// Optional args are the offset and the default value. String values are interpreted as columns.
+func Lead(arg any, args ...any) driver.Sqler {
+	return manyArgsColumn("LEAD", append([]any{arg}, args...))
+}
+
+// FirstValue generates a SQL FIRST_VALUE window function, returning the value of the first row of the window frame.
+func FirstValue(arg any) driver.Sqler {
+	return oneArgColumn("FIRST_VALUE", arg)
+}
+
+// LastValue generates a SQL LAST_VALUE window function, returning the value of the last row of the window frame.
+func LastValue(arg any) driver.Sqler {
+	return oneArgColumn("LAST_VALUE", arg)
+}
+
+// NthValue generates a SQL NTH_VALUE window function, returning the value of the n-th row of the window frame.
+func NthValue(arg any, n any) driver.Sqler {
+	if str, ok := arg.(string); ok {
+		arg = Column(str)
+	}
+
+	return Func("NTH_VALUE", arg, n)
+}
+
+// UnboundedPreceding returns the frame bound starting the frame with the first row of the partition.
+func UnboundedPreceding() driver.Sqler {
+	return driver.Pure("UNBOUNDED PRECEDING")
+}
+
+// UnboundedFollowing returns the frame bound ending the frame with the last row of the partition.
+func UnboundedFollowing() driver.Sqler {
+	return driver.Pure("UNBOUNDED FOLLOWING")
+}
+
+// CurrentRow returns the frame bound referencing the current row.
+func CurrentRow() driver.Sqler {
+	return driver.Pure("CURRENT ROW")
+}
+
+// Preceding returns the frame bound referencing the row that is the given number of rows before the current row.
+func Preceding(offset uint64) driver.Sqler {
+	return driver.Pure(strconv.FormatUint(offset, 10) + " PRECEDING")
+}
+
+// Following returns the frame bound referencing the row that is the given number of rows after the current row.
+func Following(offset uint64) driver.Sqler {
+	return driver.Pure(strconv.FormatUint(offset, 10) + " FOLLOWING")
+}
+
+// PartitionBy adds columns or expressions to the PARTITION BY clause. Accepts strings or objects implementing Sqler.
+func (w *window) PartitionBy(partitions ...any) WindowBuilder {
+	for i := range partitions {
+		switch p := partitions[i].(type) {
+		case string:
+			w.partitions = append(w.partitions, Column(p))
+		case driver.Sqler:
+			w.partitions = append(w.partitions, p)
+		default:
+			w.err = fmt.Errorf("%w: %T must be a string or driver.Sqler", ErrUnsupportedType, partitions[i])
+			return w
+		}
+	}
+
+	return w
+}
+
+// OrderBy appends one or more ordering conditions to the window and returns the updated WindowBuilder.
+func (w *window) OrderBy(orders ...Order) WindowBuilder {
+	w.orders = append(w.orders, orders...)
+	return w
+}
+
+// Rows sets the frame of the window in ROWS mode using the given start and optional end bounds.
+func (w *window) Rows(start driver.Sqler, end driver.Sqler) WindowBuilder {
+	w.frame = &frame{frameType: frameRows, start: start, end: end}
+	return w
+}
+
+// Range sets the frame of the window in RANGE mode using the given start and optional end bounds.
+func (w *window) Range(start driver.Sqler, end driver.Sqler) WindowBuilder {
+	w.frame = &frame{frameType: frameRange, start: start, end: end}
+	return w
+}
+
+// Sql generates the window definition, including base window name, PARTITION BY, ORDER BY and frame clauses.
+func (w *window) Sql(options *driver.SqlOptions) (string, []any, error) {
+	if w.err != nil {
+		return "", nil, w.err
+	}
+
+	var parts []string
+	var args []any
+
+	if !w.base.IsZero() {
+		sql, baseArgs, err := w.base.Sql(options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		args = append(args, baseArgs...)
+		parts = append(parts, sql)
+	}
+
+	if len(w.partitions) > 0 {
+		sql, partitionsArgs, err := concatFields(w.partitions, options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		args = append(args, partitionsArgs...)
+		parts = append(parts, "PARTITION BY "+sql)
+	}
+
+	if len(w.orders) > 0 {
+		sql, ordersArgs, err := concatFields(w.orders, options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		args = append(args, ordersArgs...)
+		parts = append(parts, "ORDER BY "+sql)
+	}
+
+	if w.frame != nil {
+		sql, frameArgs, err := w.frame.Sql(options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		args = append(args, frameArgs...)
+		parts = append(parts, sql)
+	}
+
+	return strings.Join(parts, " "), args, nil
+}
+
+// Sql generates the frame clause, rendering `BETWEEN start AND end` if the end bound is specified.
+func (f *frame) Sql(options *driver.SqlOptions) (string, []any, error) {
+	if f.start == nil {
+		return "", nil, fmt.Errorf("%s frame requires a start bound", f.frameType)
+	}
+
+	sqlStart, args, err := f.start.Sql(options)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if f.end == nil {
+		return f.frameType.String() + " " + sqlStart, args, nil
+	}
+
+	sqlEnd, endArgs, err := f.end.Sql(options)
+	if err != nil {
+		return "", nil, err
+	}
+
+	args = append(args, endArgs...)
+	return f.frameType.String() + " BETWEEN " + sqlStart + " AND " + sqlEnd, args, nil
+}
+
+// Sql generates the named window definition `name AS (...)` of the WINDOW clause.
+func (nw namedWindow) Sql(options *driver.SqlOptions) (string, []any, error) {
+	sqlName, args, err := nw.name.Sql(options)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if nw.window == nil {
+		return "", nil, fmt.Errorf("window %q is not defined", nw.name)
+	}
+
+	sql, windowArgs, err := nw.window.Sql(options)
+	if err != nil {
+		return "", nil, err
+	}
+
+	args = append(args, windowArgs...)
+	return sqlName + " AS (" + sql + ")", args, nil
+}
+
+// Sql generates the window function call with the OVER clause referencing a named window or an inline window definition.
+func (o *over) Sql(options *driver.SqlOptions) (string, []any, error) {
+	if o.fn == nil {
+		return "", nil, fmt.Errorf("OVER clause requires a window function")
+	}
+
+	sql, args, err := o.fn.Sql(options)
+	if err != nil {
+		return "", nil, err
+	}
+
+	switch w := o.window.(type) {
+	case string:
+		sqlName, nameArgs, err := Column(w).Sql(options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		args = append(args, nameArgs...)
+		return sql + " OVER " + sqlName, args, nil
+	case driver.Sqler:
+		sqlWindow, windowArgs, err := w.Sql(options)
+		if err != nil {
+			return "", nil, err
+		}
+
+		args = append(args, windowArgs...)
+		return sql + " OVER (" + sqlWindow + ")", args, nil
+	case nil:
+		return sql + " OVER ()", args, nil
+	}
+
+	return "", nil, fmt.Errorf("%w: %T must be a string or WindowBuilder", ErrUnsupportedType, o.window)
+}
+
+// String returns the SQL representation of the frameType, such as "ROWS" or "RANGE".
+func (f frameType) String() string {
+	if f == frameRange {
+		return "RANGE"
+	}
+
+	return "ROWS"
+}
@@ -0,0 +1,207 @@
+package op
+
+import (
+	"testing"
+
+	"github.com/xsqrty/op/driver"
+	"github.com/xsqrty/op/internal/testutil"
+)
+
+func TestWindow(t *testing.T) {
+	t.Parallel()
+	testutil.RunCases(t, options, []testutil.TestCase{
+		{
+			Name:         "row_number",
+			Builder:      Over(RowNumber(), Window().PartitionBy("company_id").OrderBy(Desc("created_at"))),
+			ExpectedSql:  `ROW_NUMBER() OVER (PARTITION BY "company_id" ORDER BY "created_at" DESC)`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "empty_window",
+			Builder:      Over(Rank(), nil),
+			ExpectedSql:  `RANK() OVER ()`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "named_window",
+			Builder:      Over(DenseRank(), "w"),
+			ExpectedSql:  `DENSE_RANK() OVER "w"`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "base_window",
+			Builder:      Over(PercentRank(), Window("w").OrderBy(Asc("id"))),
+			ExpectedSql:  `PERCENT_RANK() OVER ("w" ORDER BY "id" ASC)`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name: "running_total",
+			Builder: Over(
+				Sum("amount"),
+				Window().
+					PartitionBy("account_id", Lower("type")).
+					OrderBy(Asc("created_at")).
+					Rows(UnboundedPreceding(), CurrentRow()),
+			),
+			ExpectedSql:  `SUM("amount") OVER (PARTITION BY "account_id",LOWER("type") ORDER BY "created_at" ASC ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW)`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "range_frame",
+			Builder:      Over(Avg("amount"), Window().OrderBy(Asc("day")).Range(Preceding(3), Following(2))),
+			ExpectedSql:  `AVG("amount") OVER (ORDER BY "day" ASC RANGE BETWEEN 3 PRECEDING AND 2 FOLLOWING)`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "frame_start",
+			Builder:      Over(CumeDist(), Window().Rows(UnboundedPreceding(), nil)),
+			ExpectedSql:  `CUME_DIST() OVER (ROWS UNBOUNDED PRECEDING)`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "frame_unbounded_following",
+			Builder:      Over(LastValue("amount"), Window().Rows(CurrentRow(), UnboundedFollowing())),
+			ExpectedSql:  `LAST_VALUE("amount") OVER (ROWS BETWEEN CURRENT ROW AND UNBOUNDED FOLLOWING)`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name:         "lag_lead",
+			Builder:      And{Eq(Over(Lag("amount", 1, 0), "w"), Over(Lead("amount"), "w"))},
+			ExpectedSql:  `LAG("amount",?,?) OVER "w" = LEAD("amount") OVER "w"`,
+			ExpectedArgs: []any{1, 0},
+		},
+		{
+			Name:         "values",
+			Builder:      list{Over(FirstValue("a"), "w"), Over(NthValue("a", 2), "w"), Over(Ntile(4), "w")},
+			ExpectedSql:  `FIRST_VALUE("a") OVER "w",NTH_VALUE("a",?) OVER "w",NTILE(?) OVER "w"`,
+			ExpectedArgs: []any{2, 4},
+		},
+		{
+			Name: "select_alias",
+			Builder: Select(
+				"id",
+				As("rn", Over(RowNumber(), Window().PartitionBy("company_id").OrderBy(Asc("id")))),
+			).From("users"),
+			ExpectedSql:  `SELECT "id",(ROW_NUMBER() OVER (PARTITION BY "company_id" ORDER BY "id" ASC)) AS "rn" FROM "users"`,
+			ExpectedArgs: []any(nil),
+		},
+		{
+			Name: "select_named_windows",
+			Builder: Select(
+				As("rn", Over(RowNumber(), "w")),
+				As("total", Over(Sum("amount"), Window("w").Rows(UnboundedPreceding(), CurrentRow()))),
+			).
+				From("payments").
+				Where(Gt("amount", 10)).
+				Window("w", Window().PartitionBy("account_id").OrderBy(Asc("id"))).
+				Window("w2", Window("w")).
+				OrderBy(Asc("id")),
+			ExpectedSql:  `SELECT (ROW_NUMBER() OVER "w") AS "rn",(SUM("amount") OVER ("w" ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW)) AS "total" FROM "payments" WHERE "amount" > ? WINDOW "w" AS (PARTITION BY "account_id" ORDER BY "id" ASC),"w2" AS ("w") ORDER BY "id" ASC`,
+			ExpectedArgs: []any{10},
+		},
+		{
+			Name:         "window_args",
+			Builder:      Over(Sum("amount"), Window().PartitionBy(Add("a", driver.Value(1)))),
+			ExpectedSql:  `SUM("amount") OVER (PARTITION BY ("a"+?))`,
+			ExpectedArgs: []any{1},
+		},
+		{
+			Name:         "error_partition_type",
+			Builder:      Over(RowNumber(), Window().PartitionBy(1)),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  "unknown type: int must be a string or driver.Sqler",
+		},
+		{
+			Name:         "error_partition",
+			Builder:      Over(RowNumber(), Window().PartitionBy("a+b")),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `target "a+b" contains illegal character '+'`,
+		},
+		{
+			Name:         "error_order",
+			Builder:      Over(RowNumber(), Window().OrderBy(Asc("a+b"))),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `target "a+b" contains illegal character '+'`,
+		},
+		{
+			Name:         "error_base",
+			Builder:      Over(RowNumber(), Window("a+b")),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `target "a+b" contains illegal character '+'`,
+		},
+		{
+			Name:         "error_frame",
+			Builder:      Over(RowNumber(), Window().Rows(nil, nil)),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  "ROWS frame requires a start bound",
+		},
+		{
+			Name:         "error_frame_start",
+			Builder:      Over(RowNumber(), Window().Range(Column("a+b"), nil)),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `target "a+b" contains illegal character '+'`,
+		},
+		{
+			Name:         "error_frame_end",
+			Builder:      Over(RowNumber(), Window().Range(CurrentRow(), Column("a+b"))),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `target "a+b" contains illegal character '+'`,
+		},
+		{
+			Name:         "error_function",
+			Builder:      Over(nil, "w"),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  "OVER clause requires a window function",
+		},
+		{
+			Name:         "error_function_sql",
+			Builder:      Over(Sum("a+b"), "w"),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `target "a+b" contains illegal character '+'`,
+		},
+		{
+			Name:         "error_named",
+			Builder:      Over(RowNumber(), "a+b"),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `target "a+b" contains illegal character '+'`,
+		},
+		{
+			Name:         "error_window_type",
+			Builder:      Over(RowNumber(), 1),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  "unknown type: int must be a string or WindowBuilder",
+		},
+		{
+			Name:         "error_select_window",
+			Builder:      Select().From("users").Window("w", nil),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `window "w" is not defined`,
+		},
+		{
+			Name:         "error_select_window_name",
+			Builder:      Select().From("users").Window("a+b", Window()),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `target "a+b" contains illegal character '+'`,
+		},
+		{
+			Name:         "error_select_window_def",
+			Builder:      Select().From("users").Window("w", Window().PartitionBy("a+b")),
+			ExpectedSql:  "",
+			ExpectedArgs: []any(nil),
+			ExpectedErr:  `target "a+b" contains illegal character '+'`,
+		},
+	})
+}